@@ -1,6 +1,9 @@
 package main
 
 import (
+	"fmt"
+	"strconv"
+
 	"github.com/mikros-dev/mikros-cli/pkg/plugin"
 	"github.com/mikros-dev/mikros-cli/pkg/survey"
 )
@@ -21,40 +24,97 @@ func (p *Plugin) UIName() string {
 }
 
 func (p *Plugin) Survey() *survey.Survey {
+	// Questions are split into pages, allowing the user to go back and
+	// review the connection settings before submitting.
 	return &survey.Survey{
-		Questions: []*survey.Question{
-			{
-				Name:    "database_cache",
-				Message: "Use cache to optimize the queries?",
-				Prompt:  survey.PromptConfirm,
-			},
+		Pages: []*survey.Page{
 			{
-				Name:    "database_kind",
-				Message: "Select the database kind:",
-				Default: "mongo",
-				Options: []string{"mongo", "postgres", "mysql", "sqlserver", "sqlite"},
-				Prompt:  survey.PromptSelect,
+				Name:        "connection",
+				Title:       "Connection",
+				Description: "How the service connects to the database",
+				Questions: []*survey.Question{
+					{
+						Name:    "database_cache",
+						Message: "Use cache to optimize the queries?",
+						Prompt:  survey.PromptConfirm,
+					},
+					{
+						Name:        "database_kind",
+						Message:     "Select the database kind:",
+						Description: "The database engine used by the service.",
+						Default:     "mongo",
+						LabeledOptions: []*survey.Option{
+							{Label: "MongoDB", Value: "mongo", Hint: "document"},
+							{Label: "PostgreSQL", Value: "postgres", Hint: "relational"},
+							{Label: "MySQL", Value: "mysql", Hint: "relational"},
+							{Label: "SQL Server", Value: "sqlserver", Hint: "relational"},
+							{Label: "SQLite", Value: "sqlite", Hint: "embedded"},
+						},
+						Prompt: survey.PromptSelect,
+					},
+					{
+						Name:        "database_password",
+						Message:     "Enter the database password:",
+						Description: "It is stored as a reference to the DATABASE_PASSWORD environment variable.",
+						Prompt:      survey.PromptSecret,
+					},
+				},
 			},
 			{
-				Name:    "database_ttl",
-				Message: "Enter the TTL of the entity, if it needs to be cooled:",
-				Default: "0",
-				Prompt:  survey.PromptInput,
-			},
-			{
-				Name:    "database_collections",
-				Message: "Enter the name of additional collections (one by line):",
-				Prompt:  survey.PromptMultiline,
+				Name:        "entities",
+				Title:       "Entities",
+				Description: "How the service entities are stored",
+				Questions: []*survey.Question{
+					{
+						Name:        "database_ttl",
+						Message:     "Enter the TTL of the entity, if it needs to be cooled:",
+						Placeholder: "seconds",
+						Default:     "0",
+						Prompt:      survey.PromptInput,
+					},
+					{
+						Name:        "database_migrations",
+						Message:     "Select the directory with the database migrations:",
+						Description: "The path is kept relative to the service directory.",
+						Prompt:      survey.PromptDirectory,
+						MustExist:   true,
+					},
+					{
+						Name:        "database_collections",
+						Message:     "Enter the name of additional collections (one by line):",
+						Placeholder: "collection_name",
+						Prompt:      survey.PromptMultiline,
+					},
+				},
 			},
 		},
 	}
 }
 
 func (p *Plugin) ValidateAnswers(in map[string]interface{}) (map[string]interface{}, error) {
+	// Invalid answers are returned per question, so the user can fix them
+	// instead of losing the whole survey.
+	ttl, err := strconv.Atoi(fmt.Sprint(in["database_ttl"]))
+	if err != nil || ttl < 0 {
+		return nil, survey.NewValidationError().Add("database_ttl", "must be a positive number of seconds")
+	}
+
 	values := map[string]interface{}{
 		"enabled":     true,
 		"collections": []string{"name1", "name2"},
-		"ttl":         0,
+		"ttl":         ttl,
+	}
+
+	// The kind tells which database container the service needs to be
+	// executed locally.
+	if kind, ok := in["database_kind"].(string); ok && kind != "" {
+		values["kind"] = kind
+	}
+
+	// Secrets must never be written as plaintext into the 'service.toml'
+	// file, only a reference to them.
+	if password, ok := in["database_password"].(string); ok && password != "" {
+		values["password"] = survey.EnvReference("DATABASE_PASSWORD")
 	}
 
 	return values, nil
@@ -28,6 +28,10 @@ func (p *Plugin) Survey() *survey.Survey {
 			Message:      "Do you want to execute the form again?",
 			Default:      "true",
 		},
+		// The survey is executed at most 3 times and each entry is labelled
+		// with its chosen option.
+		MaxIterations: 3,
+		KeyQuestion:   "option-chosen",
 		Questions: []*survey.Question{
 			{
 				Name:    "option-chosen",
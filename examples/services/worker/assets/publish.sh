@@ -0,0 +1,8 @@
+#!/bin/sh
+# Publishes a test event into a topic, like: ./scripts/publish.sh my-topic '{"id": 1}'
+set -e
+
+topic="${1:?topic name is required}"
+payload="${2:-{}}"
+
+echo "publishing ${payload} into ${topic}"
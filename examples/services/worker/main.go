@@ -14,6 +14,10 @@ type Context struct {
 	EventName string
 }
 
+type ManifestContext struct {
+	StreamName string
+}
+
 type Plugin struct{}
 
 func (p *Plugin) Kind() string {
@@ -27,11 +31,15 @@ func (p *Plugin) Survey() *survey.Survey {
 			Default:      "true",
 			ConfirmAfter: true,
 		},
+		MinIterations: 1,
+		EntryName:     "Event",
+		KeyQuestion:   "topic_name",
 		Questions: []*survey.Question{
 			{
 				Name:     "topic_name",
 				Prompt:   survey.PromptInput,
 				Message:  "Topic name. The subscription topic name to subscribe into:",
+				Default:  "{{toKebab .ServiceName}}-events",
 				Required: true,
 			},
 			{
@@ -72,6 +80,28 @@ func (p *Plugin) Template(in map[string]interface{}) *mtemplate.Template {
 		WithExternalFeaturesArg: "",
 		WithExternalServicesArg: "",
 		Templates:               createTemplateFiles(in, files),
+		Tests:                   createTestFiles(in, files),
+	}
+}
+
+// Manifests adds a PodDisruptionBudget to the Kubernetes manifests of
+// worker services, so events keep being consumed during node upgrades.
+func (p *Plugin) Manifests(in map[string]interface{}) []*mtemplate.File {
+	data, err := assets.Files.ReadFile("pdb.yaml.tmpl")
+	if err != nil {
+		return nil
+	}
+
+	streamName, _ := in["stream_name"].(string)
+	return []*mtemplate.File{
+		{
+			Content:   string(data),
+			Output:    "pdb",
+			Extension: "yaml",
+			Context: ManifestContext{
+				StreamName: streamName,
+			},
+		},
 	}
 }
 
@@ -81,21 +111,59 @@ func createTemplateFiles(in map[string]interface{}, files map[string]string) []*
 		return nil
 	}
 
-	tplFiles := make([]*mtemplate.File, len(data))
-	for i, d := range data {
+	// Scripts are shipped as they are, already executable.
+	tplFiles := []*mtemplate.File{
+		{
+			Content:   files["publish.sh"],
+			Output:    "scripts/publish",
+			Extension: "sh",
+			Mode:      0755,
+			Raw:       true,
+		},
+	}
+
+	for _, d := range data {
 		entry, ok := d.(map[string]interface{})
 		if !ok {
 			continue
 		}
 
-		tplFiles[i] = &mtemplate.File{
+		tplFiles = append(tplFiles, &mtemplate.File{
 			Content:   files["event.go.tmpl"],
 			Output:    strcase.ToSnake(entry["topic_name"].(string)),
 			Extension: "go",
 			Context: Context{
 				EventName: strcase.ToCamel(entry["topic_name"].(string)),
 			},
+		})
+	}
+
+	return tplFiles
+}
+
+// createTestFiles adds a test for every event handler, generated only when
+// the service has unit tests.
+func createTestFiles(in map[string]interface{}, files map[string]string) []*mtemplate.File {
+	data, ok := in["worker"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var tplFiles []*mtemplate.File
+	for _, d := range data {
+		entry, ok := d.(map[string]interface{})
+		if !ok {
+			continue
 		}
+
+		tplFiles = append(tplFiles, &mtemplate.File{
+			Content:   files["event_test.go.tmpl"],
+			Output:    strcase.ToSnake(entry["topic_name"].(string)) + "_test",
+			Extension: "go",
+			Context: Context{
+				EventName: strcase.ToCamel(entry["topic_name"].(string)),
+			},
+		})
 	}
 
 	return tplFiles
@@ -0,0 +1,8 @@
+package container
+
+import (
+	"embed"
+)
+
+//go:embed *.tmpl
+var Files embed.FS
@@ -0,0 +1,8 @@
+package deploy
+
+import (
+	"embed"
+)
+
+//go:embed *.tmpl
+var Files embed.FS
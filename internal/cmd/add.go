@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mikros-dev/mikros-cli/internal/settings"
+)
+
+var (
+	addCmd = &cobra.Command{
+		Use:   "add",
+		Short: "Add files to an existing service",
+		Long: `add creates new files for a service that already exists, based on
+its service.toml file.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := cmd.Help(); err != nil {
+				fmt.Println("add:", err)
+				return
+			}
+		},
+	}
+)
+
+func addCmdInit(cfg *settings.Settings) {
+	addDockerCmdInit(cfg)
+	rootCmd.AddCommand(addCmd)
+}
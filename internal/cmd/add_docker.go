@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/mikros-dev/mikros-cli/internal/cmd/new/container"
+	"github.com/mikros-dev/mikros-cli/internal/settings"
+)
+
+var (
+	addDockerCmd = &cobra.Command{
+		Use:   "docker",
+		Short: "Add container packaging to a service",
+		Long: `docker creates a multi-stage Dockerfile, a .dockerignore and a
+compose.yaml, with the containers of the service features, for an
+existing service.`,
+		Args: cobra.NoArgs,
+	}
+)
+
+func addDockerCmdInit(cfg *settings.Settings) {
+	setAddDockerCmdFlags()
+	addDockerCmd.Run = func(cmd *cobra.Command, args []string) {
+		servicePath := viper.GetString("add-docker-path")
+		if servicePath == "" {
+			cwd, err := os.Getwd()
+			if err != nil {
+				fmt.Println("add:", err)
+				return
+			}
+
+			servicePath = cwd
+		}
+
+		options := &container.NewOptions{
+			Path:          servicePath,
+			Profile:       viper.GetString("add-docker-profile"),
+			DebugTemplate: viper.GetBool("add-docker-debug-template"),
+			Overwrite:     viper.GetBool("add-docker-force"),
+		}
+
+		if err := container.New(cfg, options); err != nil {
+			fmt.Println("add:", err)
+			return
+		}
+
+		fmt.Printf("✅ Container files successfully created\n")
+	}
+
+	addCmd.AddCommand(addDockerCmd)
+}
+
+func setAddDockerCmdFlags() {
+	// path option
+	addDockerCmd.Flags().String("path", "", "Sets the service directory (default cwd).")
+	_ = viper.BindPFlag("add-docker-path", addDockerCmd.Flags().Lookup("path"))
+
+	// profile option
+	addDockerCmd.Flags().String("profile", "default", "Sets the profile to use.")
+	_ = viper.BindPFlag("add-docker-profile", addDockerCmd.Flags().Lookup("profile"))
+
+	// force option
+	addDockerCmd.Flags().Bool("force", false, "Replaces files that already exist.")
+	_ = viper.BindPFlag("add-docker-force", addDockerCmd.Flags().Lookup("force"))
+
+	// debug-template option
+	addDockerCmd.Flags().Bool("debug-template", false, "Shows the context passed to templates when they fail.")
+	_ = viper.BindPFlag("add-docker-debug-template", addDockerCmd.Flags().Lookup("debug-template"))
+}
@@ -65,7 +65,8 @@ func settingsForm(cfg *settings.Settings) error {
 		huh.NewGroup(
 			huh.NewInput().Title("Feature plugins:").Value(&cfg.Paths.Plugins.Features),
 			huh.NewInput().Title("Service plugins:").Value(&cfg.Paths.Plugins.Services),
-		).Title("Paths").Description("Configure paths for plugins\n"),
+			huh.NewInput().Title("Templates (optional):").Value(&cfg.Paths.Templates),
+		).Title("Paths").Description("Configure paths for plugins and templates overriding the built-in ones\n"),
 
 		huh.NewGroup(
 			huh.NewConfirm().Title("Enable accessibility?").Value(&cfg.UI.Accessible),
@@ -245,6 +246,10 @@ func editProfile(cfg *settings.Settings, name string) error {
 				Title("Auth scopes. Enter the authentication scopes key for HTTP services:").
 				Value(&profile.Project.Templates.Protobuf.CustomAuthName).
 				Validate(ui.IsEmpty("custom auth scopes key cannot be empty")),
+
+			huh.NewInput().
+				Title("Templates path. Optional directory overriding the built-in templates for the profile:").
+				Value(&profile.Project.Templates.Path),
 		),
 	).
 		WithAccessible(cfg.UI.Accessible).
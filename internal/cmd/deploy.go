@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mikros-dev/mikros-cli/internal/settings"
+)
+
+var (
+	deployCmd = &cobra.Command{
+		Use:   "deploy",
+		Short: "Create deployment files for a service",
+		Long: `deploy creates the files to deploy a service that already exists,
+based on its service.toml file.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := cmd.Help(); err != nil {
+				fmt.Println("deploy:", err)
+				return
+			}
+		},
+	}
+)
+
+func deployCmdInit(cfg *settings.Settings) {
+	deployManifestsCmdInit(cfg)
+	rootCmd.AddCommand(deployCmd)
+}
@@ -0,0 +1,250 @@
+package manifests
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mikros-dev/mikros/components/definition"
+
+	deploy_tpl "github.com/mikros-dev/mikros-cli/internal/assets/templates/deploy"
+	"github.com/mikros-dev/mikros-cli/internal/definitions"
+	"github.com/mikros-dev/mikros-cli/internal/path"
+	"github.com/mikros-dev/mikros-cli/internal/plugin"
+	"github.com/mikros-dev/mikros-cli/internal/settings"
+	"github.com/mikros-dev/mikros-cli/internal/template"
+)
+
+type NewOptions struct {
+	// Path is the service directory, where its 'service.toml' file is.
+	Path string
+
+	// Output is the directory where manifests are written. Relative paths
+	// are relative to the service directory.
+	Output string
+
+	// Image is the container image of the service. When empty, an image
+	// named after the service and its version is used.
+	Image string
+
+	// Schedule is the cron schedule of script services.
+	Schedule string
+
+	Profile       string
+	DebugTemplate bool
+
+	// Overwrite allows replacing files that already exist.
+	Overwrite bool
+}
+
+// New creates the Kubernetes manifests of a service based on its
+// 'service.toml' file, with the resources that plugins add for their
+// service kinds.
+func New(cfg *settings.Settings, options *NewOptions) error {
+	service, err := definitions.Read(options.Path)
+	if err != nil {
+		return err
+	}
+
+	tplCtx := generateTemplateContext(service, options)
+
+	session, err := template.NewSessionFromFiles(&template.LoadOptions{
+		TemplateNames: TemplateNames(),
+		Directory:     cfg.TemplatesPath(options.Profile, "deploy"),
+		Debug:         options.DebugTemplate,
+	}, deploy_tpl.Files)
+	if err != nil {
+		return err
+	}
+
+	generated, err := session.ExecuteTemplates(tplCtx)
+	if err != nil {
+		return err
+	}
+
+	pluginGenerated, err := pluginManifests(cfg, options, service, tplCtx)
+	if err != nil {
+		return err
+	}
+	generated = append(generated, pluginGenerated...)
+
+	outputPath := options.Output
+	if !filepath.IsAbs(outputPath) {
+		outputPath = filepath.Join(options.Path, outputPath)
+	}
+
+	filenames := make([]string, len(generated))
+	for i, gen := range generated {
+		filename, err := path.JoinLocal(outputPath, gen.Filename())
+		if err != nil {
+			return fmt.Errorf("%s: %w", gen.Source(), err)
+		}
+		if !options.Overwrite && path.FindPath(filename) {
+			return fmt.Errorf("%s already exists", filename)
+		}
+
+		filenames[i] = filename
+	}
+
+	for i, gen := range generated {
+		if _, err := path.CreatePath(filepath.Dir(filenames[i])); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filenames[i], gen.Content(), 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TemplateNames returns the manifests templates.
+func TemplateNames() []template.File {
+	return []template.File{
+		{
+			Name: "configmap.yaml",
+		},
+		{
+			Name: "deployment.yaml",
+			When: "{{not .IsScript}}",
+		},
+		{
+			Name: "service.yaml",
+			When: "{{gt (len .Ports) 0}}",
+		},
+		{
+			Name: "cronjob.yaml",
+			When: "{{.IsScript}}",
+		},
+	}
+}
+
+func generateTemplateContext(service *definitions.File, options *NewOptions) TemplateContext {
+	var (
+		defs        = service.Definitions
+		serviceName = service.ServiceName()
+		types       = make([]string, len(defs.Types))
+	)
+
+	for i, t := range defs.Types {
+		types[i], _, _ = strings.Cut(t, ":")
+	}
+
+	image := options.Image
+	if image == "" {
+		image = fmt.Sprintf("%s:%s", serviceName, defs.Version)
+	}
+
+	return TemplateContext{
+		ServiceName: serviceName,
+		Types:       types,
+		Version:     defs.Version,
+		Product:     defs.Product,
+		Image:       image,
+		IsScript:    slices.Contains(types, definition.ServiceType_Script.String()),
+		Schedule:    options.Schedule,
+		Labels: map[string]string{
+			"app.kubernetes.io/name":       serviceName,
+			"app.kubernetes.io/version":    defs.Version,
+			"app.kubernetes.io/part-of":    defs.Product,
+			"app.kubernetes.io/managed-by": "mikros",
+		},
+		Ports:       service.Ports(),
+		Envs:        service.Envs(),
+		Features:    service.EnabledFeatures(),
+		Definitions: strings.TrimSpace(string(service.Content)),
+	}
+}
+
+// pluginManifests executes the manifests that plugins add for the service
+// kinds they provide.
+func pluginManifests(cfg *settings.Settings, options *NewOptions, service *definitions.File, tplCtx TemplateContext) ([]*template.GeneratedTemplate, error) {
+	var generated []*template.GeneratedTemplate
+	for _, kind := range tplCtx.Types {
+		if isBuiltInType(kind) {
+			continue
+		}
+
+		svc, err := plugin.GetServicePlugin(cfg, kind)
+		if err != nil {
+			return nil, err
+		}
+		if svc == nil {
+			// Without the plugin, only the built-in resources are created.
+			continue
+		}
+
+		manifests, err := svc.GetManifests(service.Definitions.Services[kind])
+		if err != nil {
+			// The plugin doesn't add resources, but the built-in ones are
+			// still created.
+			fmt.Printf("⚠️  Skipping manifests of plugin '%s': %v\n", kind, err)
+			continue
+		}
+
+		var (
+			templateNames = make([]template.File, len(manifests))
+			files         = make([]*template.Data, len(manifests))
+		)
+
+		for i, m := range manifests {
+			templateNames[i] = template.File{
+				Name:      m.Name,
+				Output:    m.Output,
+				Extension: m.Extension,
+				Raw:       m.IsRaw(),
+				When:      m.When,
+			}
+
+			name := templateNames[i].Name
+			if name == "" {
+				name = templateNames[i].Output
+			}
+
+			content, err := m.Bytes()
+			if err != nil {
+				return nil, fmt.Errorf("plugin manifest '%s': %w", templateNames[i].Filename(), err)
+			}
+
+			// Set the context PluginData with custom context from the plugin
+			tplCtx.PluginData = m.Context
+			files[i] = &template.Data{
+				// The extension is added so that dots in the name are kept.
+				FileName: name + ".tmpl",
+				Content:  content,
+				Context:  tplCtx,
+			}
+		}
+
+		session, err := template.NewSessionFromData(&template.LoadOptions{
+			TemplateNames: templateNames,
+			Origin:        fmt.Sprintf("plugin '%s'", kind),
+			Debug:         options.DebugTemplate,
+		}, files)
+		if err != nil {
+			return nil, err
+		}
+
+		pluginGenerated, err := session.ExecuteTemplates(nil)
+		if err != nil {
+			return nil, err
+		}
+		generated = append(generated, pluginGenerated...)
+	}
+
+	return generated, nil
+}
+
+func isBuiltInType(kind string) bool {
+	switch kind {
+	case definition.ServiceType_gRPC.String(),
+		definition.ServiceType_HTTP.String(),
+		definition.ServiceType_Script.String(),
+		definition.ServiceType_Native.String():
+		return true
+	}
+
+	return false
+}
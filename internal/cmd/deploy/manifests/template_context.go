@@ -0,0 +1,32 @@
+package manifests
+
+import (
+	"github.com/mikros-dev/mikros-cli/internal/definitions"
+)
+
+type TemplateContext struct {
+	ServiceName string
+	Types       []string
+	Version     string
+	Product     string
+	Image       string
+	IsScript    bool
+
+	// Schedule is the cron schedule of script services.
+	Schedule string
+
+	// Labels are set into every resource, identifying the service, its
+	// version and product.
+	Labels map[string]string
+
+	Ports    []*definitions.ServicePort
+	Envs     []string
+	Features []string
+
+	// Definitions is the content of the 'service.toml' file, mounted into
+	// the service container.
+	Definitions string
+
+	// PluginData is the custom context of manifests added by plugins.
+	PluginData interface{}
+}
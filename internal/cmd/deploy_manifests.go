@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/mikros-dev/mikros-cli/internal/cmd/deploy/manifests"
+	"github.com/mikros-dev/mikros-cli/internal/settings"
+)
+
+var (
+	deployManifestsCmd = &cobra.Command{
+		Use:   "manifests",
+		Short: "Create the Kubernetes manifests of a service",
+		Long: `manifests creates the Kubernetes ConfigMap, Deployment and Service of
+a service, or a CronJob for scripts, following its service.toml file.
+Service plugins can add resources of their own for their service kinds.`,
+		Args: cobra.NoArgs,
+	}
+)
+
+func deployManifestsCmdInit(cfg *settings.Settings) {
+	setDeployManifestsCmdFlags()
+	deployManifestsCmd.Run = func(cmd *cobra.Command, args []string) {
+		servicePath := viper.GetString("deploy-manifests-path")
+		if servicePath == "" {
+			cwd, err := os.Getwd()
+			if err != nil {
+				fmt.Println("deploy:", err)
+				return
+			}
+
+			servicePath = cwd
+		}
+
+		options := &manifests.NewOptions{
+			Path:          servicePath,
+			Output:        viper.GetString("deploy-manifests-output"),
+			Image:         viper.GetString("deploy-manifests-image"),
+			Schedule:      viper.GetString("deploy-manifests-schedule"),
+			Profile:       viper.GetString("deploy-manifests-profile"),
+			DebugTemplate: viper.GetBool("deploy-manifests-debug-template"),
+			Overwrite:     viper.GetBool("deploy-manifests-force"),
+		}
+
+		if err := manifests.New(cfg, options); err != nil {
+			fmt.Println("deploy:", err)
+			return
+		}
+
+		fmt.Printf("✅ Manifests successfully created\n")
+	}
+
+	deployCmd.AddCommand(deployManifestsCmd)
+}
+
+func setDeployManifestsCmdFlags() {
+	// path option
+	deployManifestsCmd.Flags().String("path", "", "Sets the service directory (default cwd).")
+	_ = viper.BindPFlag("deploy-manifests-path", deployManifestsCmd.Flags().Lookup("path"))
+
+	// output option
+	deployManifestsCmd.Flags().String("output", "deploy", "Sets the manifests directory, relative to the service directory.")
+	_ = viper.BindPFlag("deploy-manifests-output", deployManifestsCmd.Flags().Lookup("output"))
+
+	// image option
+	deployManifestsCmd.Flags().String("image", "", "Sets the service container image (default <name>:<version>).")
+	_ = viper.BindPFlag("deploy-manifests-image", deployManifestsCmd.Flags().Lookup("image"))
+
+	// schedule option
+	deployManifestsCmd.Flags().String("schedule", "@daily", "Sets the cron schedule of script services.")
+	_ = viper.BindPFlag("deploy-manifests-schedule", deployManifestsCmd.Flags().Lookup("schedule"))
+
+	// profile option
+	deployManifestsCmd.Flags().String("profile", "default", "Sets the profile to use.")
+	_ = viper.BindPFlag("deploy-manifests-profile", deployManifestsCmd.Flags().Lookup("profile"))
+
+	// force option
+	deployManifestsCmd.Flags().Bool("force", false, "Replaces files that already exist.")
+	_ = viper.BindPFlag("deploy-manifests-force", deployManifestsCmd.Flags().Lookup("force"))
+
+	// debug-template option
+	deployManifestsCmd.Flags().Bool("debug-template", false, "Shows the context passed to templates when they fail.")
+	_ = viper.BindPFlag("deploy-manifests-debug-template", deployManifestsCmd.Flags().Lookup("debug-template"))
+}
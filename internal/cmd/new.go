@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/charmbracelet/huh"
 	"github.com/spf13/cobra"
@@ -11,6 +12,9 @@ import (
 	"github.com/mikros-dev/mikros-cli/internal/cmd/new/protobuf_repository"
 	"github.com/mikros-dev/mikros-cli/internal/cmd/new/service"
 	"github.com/mikros-dev/mikros-cli/internal/cmd/new/service_repository"
+	"github.com/mikros-dev/mikros-cli/internal/cmd/new/template_pack"
+	"github.com/mikros-dev/mikros-cli/internal/pack"
+	"github.com/mikros-dev/mikros-cli/internal/session"
 	"github.com/mikros-dev/mikros-cli/internal/settings"
 )
 
@@ -25,18 +29,36 @@ var (
 func newCmdInit(cfg *settings.Settings) {
 	setNewCmdFlags()
 	newCmd.Run = func(cmd *cobra.Command, args []string) {
-		selected, err := runNewProjectForm(cfg)
+		sess, err := loadSession()
 		if err != nil {
 			fmt.Println("new:", err)
 			return
 		}
 
+		var selected string
+		if sess.IsReplay() {
+			selected = sess.Project
+		} else {
+			selected, err = runNewProjectForm(cfg)
+			if err != nil {
+				fmt.Println("new:", err)
+				return
+			}
+		}
+
+		if sess != nil && selected != "service-template" && selected != "quit" {
+			fmt.Println("new: sessions can only be recorded and replayed for service templates")
+			return
+		}
+		sess.SetProject(selected)
+
 		switch selected {
 		case "protobuf-monorepo":
 			options := &protobuf_repository.NewOptions{
-				NoVCS:   viper.GetBool("project-no-vcs"),
-				Path:    viper.GetString("project-path"),
-				Profile: viper.GetString("project-profile"),
+				NoVCS:         viper.GetBool("project-no-vcs"),
+				Path:          viper.GetString("project-path"),
+				Profile:       viper.GetString("project-profile"),
+				DebugTemplate: viper.GetBool("project-debug-template"),
 			}
 
 			if err := protobuf_repository.New(cfg, options); err != nil {
@@ -50,8 +72,10 @@ func newCmdInit(cfg *settings.Settings) {
 
 		case "services-monorepo":
 			options := &service_repository.NewOptions{
-				NoVCS: viper.GetBool("project-no-vcs"),
-				Path:  viper.GetString("project-path"),
+				NoVCS:         viper.GetBool("project-no-vcs"),
+				Path:          viper.GetString("project-path"),
+				Profile:       viper.GetString("project-profile"),
+				DebugTemplate: viper.GetBool("project-debug-template"),
 			}
 
 			if err := service_repository.New(cfg, options); err != nil {
@@ -63,7 +87,8 @@ func newCmdInit(cfg *settings.Settings) {
 
 		case "protobuf-module":
 			options := &protobuf_module.NewOptions{
-				Profile: viper.GetString("project-profile"),
+				Profile:       viper.GetString("project-profile"),
+				DebugTemplate: viper.GetBool("project-debug-template"),
 			}
 
 			if err := protobuf_module.New(cfg, options); err != nil {
@@ -75,6 +100,10 @@ func newCmdInit(cfg *settings.Settings) {
 			options := &service.NewOptions{
 				Path:          viper.GetString("project-path"),
 				ProtoFilename: viper.GetString("project-proto"),
+				Profile:       viper.GetString("project-profile"),
+				DebugTemplate: viper.GetBool("project-debug-template"),
+				Verify:        viper.GetBool("project-verify"),
+				Session:       sess,
 			}
 
 			if err := service.New(cfg, options); err != nil {
@@ -84,9 +113,39 @@ func newCmdInit(cfg *settings.Settings) {
 
 			fmt.Printf("\n✅ Service successfully created\n")
 
+			if filename := viper.GetString("project-record"); filename != "" {
+				if err := sess.Save(filename); err != nil {
+					fmt.Println("new:", err)
+					return
+				}
+
+				fmt.Printf("\nSession recorded into %s\n", filename)
+			}
+
 		case "quit":
 			// Just quits
 			return
+
+		default:
+			packName, kind, ok := strings.Cut(strings.TrimPrefix(selected, packProjectPrefix), "/")
+			if !strings.HasPrefix(selected, packProjectPrefix) || !ok {
+				fmt.Println("new: unknown project", selected)
+				return
+			}
+
+			options := &template_pack.NewOptions{
+				Path:          viper.GetString("project-path"),
+				Pack:          packName,
+				Kind:          kind,
+				DebugTemplate: viper.GetBool("project-debug-template"),
+			}
+
+			if err := template_pack.New(cfg, options); err != nil {
+				fmt.Println("new:", err)
+				return
+			}
+
+			fmt.Printf("\n✅ Project successfully created\n")
 		}
 	}
 
@@ -109,21 +168,73 @@ func setNewCmdFlags() {
 	// profile option
 	newCmd.Flags().String("profile", "default", "Sets the profile to use.")
 	_ = viper.BindPFlag("project-profile", newCmd.Flags().Lookup("profile"))
+
+	// record option
+	newCmd.Flags().String("record", "", "Records every answer given into a session file.")
+	_ = viper.BindPFlag("project-record", newCmd.Flags().Lookup("record"))
+
+	// replay option
+	newCmd.Flags().String("replay", "", "Replays a recorded session file without asking anything.")
+	_ = viper.BindPFlag("project-replay", newCmd.Flags().Lookup("replay"))
+
+	// debug-template option
+	newCmd.Flags().Bool("debug-template", false, "Shows the context passed to templates when they fail.")
+	_ = viper.BindPFlag("project-debug-template", newCmd.Flags().Lookup("debug-template"))
+
+	// verify option
+	newCmd.Flags().Bool("verify", false, "Builds the generated service, offline, reporting compile errors.")
+	_ = viper.BindPFlag("project-verify", newCmd.Flags().Lookup("verify"))
+}
+
+// loadSession returns the session used to record or replay answers, if
+// any was requested.
+func loadSession() (*session.Session, error) {
+	if filename := viper.GetString("project-replay"); filename != "" {
+		return session.Load(filename)
+	}
+	if viper.GetString("project-record") != "" {
+		return session.New(), nil
+	}
+
+	return nil, nil
 }
 
+// packProjectPrefix identifies, among the projects to create, the ones
+// provided by template packs, as "pack:<pack>/<kind>".
+const packProjectPrefix = "pack:"
+
 func runNewProjectForm(cfg *settings.Settings) (string, error) {
+	options := []huh.Option[string]{
+		huh.NewOption("Protobuf monorepo", "protobuf-monorepo"),
+		huh.NewOption("Services monorepo", "services-monorepo"),
+		huh.NewOption("Protobuf module file(s)", "protobuf-module"),
+		huh.NewOption("Single service template", "service-template"),
+	}
+
+	packs, err := pack.List(cfg)
+	if err != nil {
+		return "", err
+	}
+	for _, p := range packs {
+		for _, k := range p.Kinds {
+			label := k.Description
+			if label == "" {
+				label = k.Name
+			}
+
+			options = append(options, huh.NewOption(
+				fmt.Sprintf("%s (%s %s)", label, p.Name, p.Version),
+				packProjectPrefix+p.Name+"/"+k.Name,
+			))
+		}
+	}
+
 	var selectedProject string
 	form := huh.NewForm(
 		huh.NewGroup(
 			huh.NewSelect[string]().
 				Title("Select a project to create or Quit to exit the application").
-				Options(
-					huh.NewOption("Protobuf monorepo", "protobuf-monorepo"),
-					huh.NewOption("Services monorepo", "services-monorepo"),
-					huh.NewOption("Protobuf module file(s)", "protobuf-module"),
-					huh.NewOption("Single service template", "service-template"),
-					huh.NewOption("Quit", "quit"),
-				).
+				Options(append(options, huh.NewOption("Quit", "quit"))...).
 				Value(&selectedProject),
 		),
 	).
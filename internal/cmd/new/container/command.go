@@ -0,0 +1,195 @@
+package container
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	container_tpl "github.com/mikros-dev/mikros-cli/internal/assets/templates/container"
+	"github.com/mikros-dev/mikros-cli/internal/definitions"
+	"github.com/mikros-dev/mikros-cli/internal/path"
+	"github.com/mikros-dev/mikros-cli/internal/settings"
+	"github.com/mikros-dev/mikros-cli/internal/template"
+)
+
+const (
+	defaultGoVersion = "1.23"
+)
+
+var (
+	goVersionRegex = regexp.MustCompile(`(?m)^go\s+(\S+)\s*$`)
+
+	// dependencies are the containers that features need to be executed
+	// locally, by the 'kind' of the feature settings or by its name.
+	dependencies = map[string]*Dependency{
+		"mongo": {
+			Image: "mongo:7",
+			Port:  27017,
+		},
+		"postgres": {
+			Image: "postgres:16",
+			Port:  5432,
+			Environment: map[string]string{
+				"POSTGRES_PASSWORD": "postgres",
+			},
+		},
+		"mysql": {
+			Image: "mysql:8",
+			Port:  3306,
+			Environment: map[string]string{
+				"MYSQL_ROOT_PASSWORD": "mysql",
+			},
+		},
+		"redis": {
+			Image: "redis:7",
+			Port:  6379,
+		},
+	}
+
+	// featureDependencies are the dependencies of features that don't say
+	// their kind, by the feature name.
+	featureDependencies = map[string]string{
+		"database": "mongo",
+		"cache":    "redis",
+	}
+)
+
+type NewOptions struct {
+	// Path is the service directory, where its 'service.toml' file is.
+	Path          string
+	Profile       string
+	DebugTemplate bool
+
+	// Overwrite allows replacing files that already exist.
+	Overwrite bool
+}
+
+// New creates the files to build a service container image and to execute
+// it locally, with the containers of its features, based on its
+// 'service.toml' file.
+func New(cfg *settings.Settings, options *NewOptions) error {
+	service, err := definitions.Read(options.Path)
+	if err != nil {
+		return err
+	}
+
+	tplCtx, err := generateTemplateContext(options.Path, service)
+	if err != nil {
+		return err
+	}
+
+	session, err := template.NewSessionFromFiles(&template.LoadOptions{
+		TemplateNames: TemplateNames(),
+		Directory:     cfg.TemplatesPath(options.Profile, "container"),
+		Debug:         options.DebugTemplate,
+	}, container_tpl.Files)
+	if err != nil {
+		return err
+	}
+
+	generated, err := session.ExecuteTemplates(tplCtx)
+	if err != nil {
+		return err
+	}
+
+	for _, gen := range generated {
+		filename := filepath.Join(options.Path, gen.Filename())
+		if !options.Overwrite && path.FindPath(filename) {
+			return fmt.Errorf("%s already exists", gen.Filename())
+		}
+	}
+
+	for _, gen := range generated {
+		if err := os.WriteFile(filepath.Join(options.Path, gen.Filename()), gen.Content(), 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TemplateNames returns the container templates.
+func TemplateNames() []template.File {
+	return []template.File{
+		{
+			Name: "Dockerfile",
+		},
+		{
+			Name: ".dockerignore",
+		},
+		{
+			Name: "compose.yaml",
+		},
+	}
+}
+
+func generateTemplateContext(servicePath string, service *definitions.File) (*TemplateContext, error) {
+	defs := service.Definitions
+
+	goVersion, err := readGoVersion(servicePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TemplateContext{
+		ServiceName:  service.ServiceName(),
+		Language:     defs.Language,
+		GoVersion:    goVersion,
+		Ports:        service.Ports(),
+		Envs:         service.Envs(),
+		Dependencies: featuresDependencies(service),
+	}, nil
+}
+
+// readGoVersion returns the Go version of the service module, to build it
+// with the same version.
+func readGoVersion(servicePath string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(servicePath, "go.mod"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return defaultGoVersion, nil
+		}
+
+		return "", err
+	}
+
+	if m := goVersionRegex.FindSubmatch(data); m != nil {
+		return string(m[1]), nil
+	}
+
+	return defaultGoVersion, nil
+}
+
+func featuresDependencies(service *definitions.File) []*Dependency {
+	var deps []*Dependency
+	for _, name := range service.EnabledFeatures() {
+		kind, ok := service.Features[name]["kind"].(string)
+		if !ok {
+			kind = name
+			if dep, ok := featureDependencies[name]; ok {
+				kind = dep
+			}
+		}
+
+		dep, ok := dependencies[kind]
+		if !ok || slices.ContainsFunc(deps, func(d *Dependency) bool { return d.Name == kind }) {
+			continue
+		}
+
+		deps = append(deps, &Dependency{
+			Name:        kind,
+			Image:       dep.Image,
+			Port:        dep.Port,
+			Environment: dep.Environment,
+		})
+	}
+
+	slices.SortFunc(deps, func(a, b *Dependency) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return deps
+}
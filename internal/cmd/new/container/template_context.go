@@ -0,0 +1,23 @@
+package container
+
+import (
+	"github.com/mikros-dev/mikros-cli/internal/definitions"
+)
+
+type TemplateContext struct {
+	ServiceName  string
+	Language     string
+	GoVersion    string
+	Ports        []*definitions.ServicePort
+	Envs         []string
+	Dependencies []*Dependency
+}
+
+// Dependency is a container that the service needs to be executed locally,
+// like its database.
+type Dependency struct {
+	Name        string
+	Image       string
+	Port        int32
+	Environment map[string]string
+}
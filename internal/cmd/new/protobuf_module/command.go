@@ -16,7 +16,8 @@ import (
 )
 
 type NewOptions struct {
-	Profile string
+	Profile       string
+	DebugTemplate bool
 }
 
 func New(cfg *settings.Settings, options *NewOptions) error {
@@ -176,6 +177,8 @@ func generateProtobufFiles(cfg *settings.Settings, basePath string, answers *Ans
 
 	session, err := template.NewSessionFromFiles(&template.LoadOptions{
 		TemplateNames: tplFiles,
+		Directory:     cfg.TemplatesPath(options.Profile, "protobuf_module"),
+		Debug:         options.DebugTemplate,
 	}, protobuf_module.Files)
 	if err != nil {
 		return err
@@ -1,6 +1,7 @@
 package protobuf_module
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/iancoleman/strcase"
@@ -81,6 +82,36 @@ func (c *Context) Extension() string {
 	return "proto"
 }
 
+// contextFields is Context without its methods, so it can be encoded
+// without recursion.
+type contextFields Context
+
+// contextJSON represents a Context as JSON, exposing the fields set by the
+// survey answers by the names templates use them.
+type contextJSON struct {
+	contextFields
+	IsHTTPService bool
+}
+
+func (c *Context) MarshalJSON() ([]byte, error) {
+	return json.Marshal(contextJSON{
+		contextFields: contextFields(*c),
+		IsHTTPService: c.httpService,
+	})
+}
+
+func (c *Context) UnmarshalJSON(data []byte) error {
+	var v contextJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*c = Context(v.contextFields)
+	c.httpService = v.IsHTTPService
+
+	return nil
+}
+
 type RPC struct {
 	IsAuthenticated bool
 	Name            string
@@ -19,9 +19,10 @@ import (
 )
 
 type NewOptions struct {
-	NoVCS   bool
-	Path    string
-	Profile string
+	NoVCS         bool
+	Path          string
+	Profile       string
+	DebugTemplate bool
 }
 
 func New(cfg *settings.Settings, options *NewOptions) error {
@@ -30,14 +31,14 @@ func New(cfg *settings.Settings, options *NewOptions) error {
 		return err
 	}
 
-	if err := generateProject(options, answers); err != nil {
+	if err := generateProject(cfg, options, answers); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func generateProject(options *NewOptions, answers *surveyAnswers) error {
+func generateProject(cfg *settings.Settings, options *NewOptions, answers *surveyAnswers) error {
 	repositoryPath, err := createProjectDirectory(options, answers.RepositoryName)
 	if err != nil {
 		return err
@@ -56,7 +57,7 @@ func generateProject(options *NewOptions, answers *surveyAnswers) error {
 	}()
 
 	// Notice that, starting from here, we're inside the project directory.
-	if err := createProjectTemplates(answers, repositoryPath); err != nil {
+	if err := createProjectTemplates(cfg, options, answers, repositoryPath); err != nil {
 		return err
 	}
 
@@ -108,29 +109,29 @@ func projectModuleName(answers *surveyAnswers) string {
 	return fmt.Sprintf("%s/%s", answers.VcsPath, strings.ToLower(strcase.ToKebab(answers.RepositoryName)))
 }
 
-func createProjectTemplates(answer *surveyAnswers, repositoryPath string) error {
+func createProjectTemplates(cfg *settings.Settings, options *NewOptions, answer *surveyAnswers, repositoryPath string) error {
 	tplCtx := &TemplateContext{
 		MainPackageName:  answer.ProjectName,
 		RepositoryName:   answer.RepositoryName,
 		VCSProjectPrefix: answer.VcsPath,
 	}
 
-	if err := createProjectRootTemplates(tplCtx); err != nil {
+	if err := createProjectRootTemplates(tplCtx, templateOptions(cfg, options, "protobuf_repository/root")); err != nil {
 		return err
 	}
 
-	if err := createProjectScriptsTemplates(repositoryPath, tplCtx); err != nil {
+	if err := createProjectScriptsTemplates(repositoryPath, tplCtx, templateOptions(cfg, options, "protobuf_repository/scripts")); err != nil {
 		return err
 	}
 
-	if err := createProjectProtoTemplates(repositoryPath, tplCtx); err != nil {
+	if err := createProjectProtoTemplates(repositoryPath, tplCtx, templateOptions(cfg, options, "protobuf_repository/proto")); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func createProjectRootTemplates(tplCtx *TemplateContext) error {
+func createProjectRootTemplates(tplCtx *TemplateContext, loadOptions *template.LoadOptions) error {
 	templates := []template.File{
 		{
 			Name: "buf.gen.yaml",
@@ -146,9 +147,8 @@ func createProjectRootTemplates(tplCtx *TemplateContext) error {
 		},
 	}
 
-	session, err := template.NewSessionFromFiles(&template.LoadOptions{
-		TemplateNames: templates,
-	}, root_tpl.Files)
+	loadOptions.TemplateNames = templates
+	session, err := template.NewSessionFromFiles(loadOptions, root_tpl.Files)
 	if err != nil {
 		return err
 	}
@@ -160,7 +160,7 @@ func createProjectRootTemplates(tplCtx *TemplateContext) error {
 	return nil
 }
 
-func createProjectScriptsTemplates(repositoryPath string, tplCtx *TemplateContext) error {
+func createProjectScriptsTemplates(repositoryPath string, tplCtx *TemplateContext, loadOptions *template.LoadOptions) error {
 	templates := []template.File{
 		{
 			Name: "generate.sh",
@@ -188,9 +188,8 @@ func createProjectScriptsTemplates(repositoryPath string, tplCtx *TemplateContex
 		}
 	}()
 
-	session, err := template.NewSessionFromFiles(&template.LoadOptions{
-		TemplateNames: templates,
-	}, scripts_tpl.Files)
+	loadOptions.TemplateNames = templates
+	session, err := template.NewSessionFromFiles(loadOptions, scripts_tpl.Files)
 	if err != nil {
 		return err
 	}
@@ -208,7 +207,7 @@ func createProjectScriptsTemplates(repositoryPath string, tplCtx *TemplateContex
 	return nil
 }
 
-func createProjectProtoTemplates(repositoryPath string, tplCtx *TemplateContext) error {
+func createProjectProtoTemplates(repositoryPath string, tplCtx *TemplateContext, loadOptions *template.LoadOptions) error {
 	templates := []template.File{
 		{
 			Name: "example.proto",
@@ -230,9 +229,8 @@ func createProjectProtoTemplates(repositoryPath string, tplCtx *TemplateContext)
 		}
 	}()
 
-	session, err := template.NewSessionFromFiles(&template.LoadOptions{
-		TemplateNames: templates,
-	}, proto_tpl.Files)
+	loadOptions.TemplateNames = templates
+	session, err := template.NewSessionFromFiles(loadOptions, proto_tpl.Files)
 	if err != nil {
 		return err
 	}
@@ -244,6 +242,14 @@ func createProjectProtoTemplates(repositoryPath string, tplCtx *TemplateContext)
 	return nil
 }
 
+// templateOptions returns the options to load a set of templates.
+func templateOptions(cfg *settings.Settings, options *NewOptions, set string) *template.LoadOptions {
+	return &template.LoadOptions{
+		Directory: cfg.TemplatesPath(options.Profile, set),
+		Debug:     options.DebugTemplate,
+	}
+}
+
 func runTemplates(session *template.Session, context interface{}) error {
 	generated, err := session.ExecuteTemplates(context)
 	if err != nil {
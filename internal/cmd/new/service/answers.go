@@ -5,16 +5,19 @@ import (
 	"github.com/mikros-dev/mikros-cli/internal/protobuf"
 
 	"github.com/mikros-dev/mikros-cli/internal/template"
+	"github.com/mikros-dev/mikros-cli/internal/ui"
 )
 
 type surveyAnswers struct {
-	Name      string
-	Type      string
-	Language  string
-	Version   string `default:"v0.1.0"`
-	Product   string
-	Features  []string
-	Lifecycle []string
+	Name      string   `json:"name"`
+	Type      string   `json:"type"`
+	Language  string   `json:"language"`
+	Version   string   `json:"version" default:"v0.1.0"`
+	Product   string   `json:"product"`
+	Features  []string `json:"features,omitempty"`
+	Lifecycle []string `json:"lifecycle,omitempty"`
+	UnitTests bool     `json:"unit_tests,omitempty"`
+	Container bool     `json:"container,omitempty"`
 
 	serviceAnswers     map[string]interface{}
 	featureDefinitions map[string]*surveyAnswersDefinitions
@@ -45,8 +48,9 @@ func loadProtoValues(protoFilename string, a *surveyAnswers) (*surveyAnswers, er
 	return a, nil
 }
 
-func (s *surveyAnswers) TemplateNames() []template.File {
-	names := []template.File{
+// TemplateNames returns the built-in templates of a service.
+func TemplateNames() []template.File {
+	return []template.File{
 		{
 			Name:      "main",
 			Extension: "go",
@@ -59,16 +63,22 @@ func (s *surveyAnswers) TemplateNames() []template.File {
 			Name:      "README",
 			Extension: "md",
 		},
-	}
-
-	if len(s.Lifecycle) > 0 {
-		names = append(names, template.File{
+		{
 			Name:      "lifecycle",
 			Extension: "go",
-		})
+			When:      "{{or .HasOnStart .HasOnFinish}}",
+		},
+		{
+			Name:      "main_test",
+			Extension: "go",
+			When:      "{{.HasUnitTests}}",
+		},
+		{
+			Name:      "service_test",
+			Extension: "go",
+			When:      "{{and .HasUnitTests .HasGrpcMethods (or .IsGrpcService .IsHttpService)}}",
+		},
 	}
-
-	return names
 }
 
 func (s *surveyAnswers) AddFeatureDefinitions(name string, answers interface{}) {
@@ -91,6 +101,16 @@ func (s *surveyAnswers) SetServiceAnswers(answers map[string]interface{}) {
 	s.serviceAnswers = answers
 }
 
+func (s *surveyAnswers) SurveyContext(directory string) *ui.SurveyContext {
+	return &ui.SurveyContext{
+		ServiceName: s.Name,
+		ServiceType: s.Type,
+		Product:     s.Product,
+		Language:    s.Language,
+		Directory:   directory,
+	}
+}
+
 func (s *surveyAnswers) ServiceDefinitions() *surveyAnswersDefinitions {
 	return s.serviceDefinitions
 }
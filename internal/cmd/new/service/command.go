@@ -11,11 +11,13 @@ import (
 	"github.com/mikros-dev/mikros/components/definition"
 
 	service_tpl "github.com/mikros-dev/mikros-cli/internal/assets/templates/service"
+	"github.com/mikros-dev/mikros-cli/internal/cmd/new/container"
 	"github.com/mikros-dev/mikros-cli/internal/definitions"
 	"github.com/mikros-dev/mikros-cli/internal/golang"
 	"github.com/mikros-dev/mikros-cli/internal/path"
 	"github.com/mikros-dev/mikros-cli/internal/plugin/client"
 	"github.com/mikros-dev/mikros-cli/internal/protobuf"
+	"github.com/mikros-dev/mikros-cli/internal/session"
 	"github.com/mikros-dev/mikros-cli/internal/settings"
 	"github.com/mikros-dev/mikros-cli/internal/template"
 	mtemplate "github.com/mikros-dev/mikros-cli/pkg/template"
@@ -24,23 +26,32 @@ import (
 type NewOptions struct {
 	Path          string
 	ProtoFilename string
+	Profile       string
+	DebugTemplate bool
+
+	// Verify builds the generated service, reporting compile errors.
+	Verify bool
+
+	// Session records the answers given by the user or, when replaying,
+	// provides them instead of asking.
+	Session *session.Session
 }
 
 // New creates a new service template directory with initial source files.
 func New(cfg *settings.Settings, options *NewOptions) error {
-	answers, err := runSurvey(cfg, options.ProtoFilename)
+	answers, err := runSurvey(cfg, options)
 	if err != nil {
 		return err
 	}
 
-	svc, err := runServiceSurvey(cfg, answers)
+	svc, err := runServiceSurvey(cfg, options, answers)
 	if err != nil {
 		return err
 	}
 
 	// Presents only questions from selected features
 	for _, name := range answers.Features {
-		featureName, defs, err := runFeatureSurvey(cfg, name)
+		featureName, defs, err := runFeatureSurvey(cfg, options, name, answers)
 		if err != nil {
 			return err
 		}
@@ -49,26 +60,22 @@ func New(cfg *settings.Settings, options *NewOptions) error {
 		}
 	}
 
-	if err := generateTemplates(options, answers, svc); err != nil {
+	if err := generateTemplates(cfg, options, answers, svc); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func generateTemplates(options *NewOptions, answers *surveyAnswers, svc *client.Service) error {
-	var (
-		destinationPath = filepath.Join(options.Path, strings.ToLower(answers.Name))
-	)
-
-	// Set the project base path
-	if destinationPath == "" {
-		cwd, err := os.Getwd()
-		if err != nil {
-			return err
-		}
+// serviceDirectory returns the absolute path where the service is created.
+func serviceDirectory(options *NewOptions, answers *surveyAnswers) (string, error) {
+	return filepath.Abs(filepath.Join(options.Path, strings.ToLower(answers.Name)))
+}
 
-		destinationPath = filepath.Join(cwd, strings.ToLower(answers.Name))
+func generateTemplates(cfg *settings.Settings, options *NewOptions, answers *surveyAnswers, svc *client.Service) error {
+	destinationPath, err := serviceDirectory(options, answers)
+	if err != nil {
+		return err
 	}
 
 	if _, err := path.CreatePath(destinationPath); err != nil {
@@ -98,10 +105,27 @@ func generateTemplates(options *NewOptions, answers *surveyAnswers, svc *client.
 	}
 
 	// creates go source templates
-	if err := generateSources(options, answers, svc); err != nil {
+	generated, err := generateSources(cfg, options, answers, svc)
+	if err != nil {
 		return err
 	}
 
+	if answers.Container {
+		if err := container.New(cfg, &container.NewOptions{
+			Path:          destinationPath,
+			Profile:       options.Profile,
+			DebugTemplate: options.DebugTemplate,
+		}); err != nil {
+			return err
+		}
+	}
+
+	if options.Verify {
+		if err := verifyService(cfg, options, generated); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -135,26 +159,63 @@ func writeServiceDefinitions(path string, answers *surveyAnswers) error {
 	return nil
 }
 
-func generateSources(options *NewOptions, answers *surveyAnswers, svc *client.Service) error {
-	var externalTemplate *mtemplate.Template
-	if svc != nil {
-		res, err := svc.GetTemplates(answers.ServiceAnswers())
-		if err != nil {
-			return err
-		}
-		externalTemplate = res
+func generateSources(cfg *settings.Settings, options *NewOptions, answers *surveyAnswers, svc *client.Service) ([]*template.GeneratedTemplate, error) {
+	externalTemplate, err := getExternalTemplate(answers, svc)
+	if err != nil {
+		return nil, err
 	}
 
 	tplCtx, err := generateTemplateContext(options, answers, externalTemplate)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if err := createServiceTemplates(answers.TemplateNames(), tplCtx, externalTemplate); err != nil {
-		return err
+	return createServiceTemplates(cfg, options, answers, tplCtx, externalTemplate)
+}
+
+// ReplayTemplateContext returns the context given to the service templates
+// for the answers of a replayed session, without creating the service.
+// Feature surveys are not replayed, since their answers are not part of the
+// context.
+func ReplayTemplateContext(cfg *settings.Settings, sess *session.Session, protoFilename string) (TemplateContext, error) {
+	options := &NewOptions{
+		ProtoFilename: protoFilename,
+		Session:       sess,
 	}
 
-	return nil
+	answers, err := runSurvey(cfg, options)
+	if err != nil {
+		return TemplateContext{}, err
+	}
+
+	svc, err := runServiceSurvey(cfg, options, answers)
+	if err != nil {
+		return TemplateContext{}, err
+	}
+
+	externalTemplate, err := getExternalTemplate(answers, svc)
+	if err != nil {
+		return TemplateContext{}, err
+	}
+
+	return generateTemplateContext(options, answers, externalTemplate)
+}
+
+// AnswersSession returns a Session replaying the answers of the service
+// survey, like the ones recorded by session files.
+func AnswersSession(answers map[string]interface{}) *session.Session {
+	return session.NewReplay("service-template", map[string]interface{}{
+		mainSurveyKey: answers,
+	})
+}
+
+// getExternalTemplate returns the templates of the service plugin, if any.
+func getExternalTemplate(answers *surveyAnswers, svc *client.Service) (*mtemplate.Template, error) {
+	if svc == nil {
+		return nil, nil
+	}
+
+	return svc.GetTemplates(answers.ServiceAnswers())
 }
 
 func generateTemplateContext(options *NewOptions, answers *surveyAnswers, externalTemplate *mtemplate.Template) (TemplateContext, error) {
@@ -189,6 +250,7 @@ func generateTemplateContext(options *NewOptions, answers *surveyAnswers, extern
 		servicesExtensions:       externalService(),
 		onStartLifecycle:         slices.Contains(answers.Lifecycle, "OnStart"),
 		onFinishLifecycle:        slices.Contains(answers.Lifecycle, "OnFinish"),
+		unitTests:                answers.UnitTests,
 		serviceType:              answers.Type,
 		NewServiceArgs:           newServiceArgs,
 		ServiceName:              answers.Name,
@@ -296,83 +358,166 @@ func generateImports(answers *surveyAnswers) map[string][]ImportContext {
 		})
 	}
 
+	if answers.UnitTests {
+		imports["main_test"] = []ImportContext{
+			{
+				Path: "os",
+			},
+			{
+				Path: "testing",
+			},
+			{
+				Path: "github.com/mikros-dev/mikros",
+			},
+			{
+				Path: "github.com/mikros-dev/mikros/components/options",
+			},
+		}
+		imports["service_test"] = []ImportContext{
+			{
+				Path: "context",
+			},
+			{
+				Path: "testing",
+			},
+		}
+	}
+
 	return imports
 }
 
-func createServiceTemplates(filenames []template.File, tplContext TemplateContext, externalTemplate *mtemplate.Template) error {
+func createServiceTemplates(
+	cfg *settings.Settings,
+	options *NewOptions,
+	answers *surveyAnswers,
+	tplContext TemplateContext,
+	externalTemplate *mtemplate.Template,
+) ([]*template.GeneratedTemplate, error) {
+	templatesPath := cfg.TemplatesPath(options.Profile, "service")
+
 	// Execute our templates
 	session, err := template.NewSessionFromFiles(&template.LoadOptions{
-		TemplateNames: filenames,
+		TemplateNames: TemplateNames(),
+		Directory:     templatesPath,
+		Debug:         options.DebugTemplate,
 	}, service_tpl.Files)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if err := runTemplates(session, tplContext); err != nil {
-		return err
+	generated, err := runTemplates(session, tplContext)
+	if err != nil {
+		return nil, err
 	}
 
 	// Then execute templates from the selected plugin (if any).
 	if externalTemplate != nil {
-		templateNames := make([]template.File, len(externalTemplate.Templates))
-		for i, t := range externalTemplate.Templates {
+		// Plugin tests are only generated along with the service ones.
+		pluginTemplates := externalTemplate.Templates
+		if answers.UnitTests {
+			pluginTemplates = append(slices.Clip(pluginTemplates), externalTemplate.Tests...)
+		}
+
+		templateNames := make([]template.File, len(pluginTemplates))
+		for i, t := range pluginTemplates {
 			templateNames[i] = template.File{
 				Name:      t.Name,
 				Output:    t.Output,
 				Extension: t.Extension,
+				Mode:      t.Mode,
+				Raw:       t.IsRaw(),
+				When:      t.When,
+			}
+
+			// Plugins can only write inside the service directory.
+			if _, err := path.JoinLocal(".", templateNames[i].Filename()); err != nil {
+				return nil, fmt.Errorf("plugin template: %w", err)
 			}
 		}
 
 		files := make([]*template.Data, len(templateNames))
-		for i, t := range externalTemplate.Templates {
+		for i, t := range pluginTemplates {
 			name := templateNames[i].Name
 			if name == "" {
 				name = templateNames[i].Output
 			}
 
+			content, err := t.Bytes()
+			if err != nil {
+				return nil, fmt.Errorf("plugin template '%s': %w", templateNames[i].Filename(), err)
+			}
+
 			// Set the context PluginData with custom context from the plugin
 			tplContext.PluginData = t.Context
 			files[i] = &template.Data{
-				FileName: name,
-				Content:  []byte(t.Content),
+				// The extension is added so that dots in the name are kept.
+				FileName: name + ".tmpl",
+				Content:  content,
 				Context:  tplContext,
 			}
 		}
 
+		partials, err := template.Partials(templatesPath, service_tpl.Files)
+		if err != nil {
+			return nil, err
+		}
+		for name, content := range externalTemplate.Partials {
+			partials[name] = content
+		}
+
 		session, err := template.NewSessionFromData(&template.LoadOptions{
 			TemplateNames: templateNames,
+			Partials:      partials,
+			Origin:        fmt.Sprintf("plugin '%s'", answers.Type),
+			Debug:         options.DebugTemplate,
 		}, files)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		if err := runTemplates(session, nil); err != nil {
-			return err
+		pluginGenerated, err := runTemplates(session, nil)
+		if err != nil {
+			return nil, err
 		}
+		generated = append(generated, pluginGenerated...)
 	}
 
-	return nil
+	return generated, nil
 }
 
-func runTemplates(session *template.Session, context interface{}) error {
+func runTemplates(session *template.Session, context interface{}) ([]*template.GeneratedTemplate, error) {
 	generated, err := session.ExecuteTemplates(context)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	for _, gen := range generated {
-		file, err := os.Create(gen.Filename())
+		filename, err := path.JoinLocal(".", gen.Filename())
 		if err != nil {
-			return err
+			return nil, err
+		}
+		if _, err := path.CreatePath(filepath.Dir(filename)); err != nil {
+			return nil, err
+		}
+
+		file, err := os.Create(filename)
+		if err != nil {
+			return nil, err
 		}
 
 		if _, err := file.Write(gen.Content()); err != nil {
 			_ = file.Close()
-			return err
+			return nil, err
 		}
 
 		_ = file.Close()
+
+		if mode := gen.Mode(); mode != 0 {
+			if err := os.Chmod(filename, mode); err != nil {
+				return nil, err
+			}
+		}
 	}
 
-	return nil
+	return generated, nil
 }
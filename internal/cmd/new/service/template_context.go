@@ -1,6 +1,8 @@
 package service
 
 import (
+	"encoding/json"
+
 	"github.com/mikros-dev/mikros/components/definition"
 
 	"github.com/mikros-dev/mikros-cli/internal/protobuf"
@@ -11,6 +13,7 @@ type TemplateContext struct {
 	servicesExtensions bool
 	onStartLifecycle   bool
 	onFinishLifecycle  bool
+	unitTests          bool
 	serviceType        string
 
 	ExternalFeaturesArg      string
@@ -71,3 +74,52 @@ func (t TemplateContext) HasOnStart() bool {
 func (t TemplateContext) HasOnFinish() bool {
 	return t.onFinishLifecycle
 }
+
+func (t TemplateContext) HasUnitTests() bool {
+	return t.unitTests
+}
+
+// templateContextFields is TemplateContext without its methods, so it can
+// be encoded without recursion.
+type templateContextFields TemplateContext
+
+// templateContextJSON represents a TemplateContext as JSON, exposing the
+// fields set by the survey answers by the names templates use them.
+type templateContextJSON struct {
+	templateContextFields
+	ServiceType           string
+	HasFeaturesExtensions bool
+	HasServicesExtensions bool
+	HasOnStart            bool
+	HasOnFinish           bool
+	HasUnitTests          bool
+}
+
+func (t TemplateContext) MarshalJSON() ([]byte, error) {
+	return json.Marshal(templateContextJSON{
+		templateContextFields: templateContextFields(t),
+		ServiceType:           t.serviceType,
+		HasFeaturesExtensions: t.featuresExtensions,
+		HasServicesExtensions: t.servicesExtensions,
+		HasOnStart:            t.onStartLifecycle,
+		HasOnFinish:           t.onFinishLifecycle,
+		HasUnitTests:          t.unitTests,
+	})
+}
+
+func (t *TemplateContext) UnmarshalJSON(data []byte) error {
+	var v templateContextJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	*t = TemplateContext(v.templateContextFields)
+	t.serviceType = v.ServiceType
+	t.featuresExtensions = v.HasFeaturesExtensions
+	t.servicesExtensions = v.HasServicesExtensions
+	t.onStartLifecycle = v.HasOnStart
+	t.onFinishLifecycle = v.HasOnFinish
+	t.unitTests = v.HasUnitTests
+
+	return nil
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"sort"
 
 	"github.com/charmbracelet/huh"
@@ -11,9 +12,13 @@ import (
 	"github.com/mikros-dev/mikros-cli/internal/plugin/client"
 	"github.com/mikros-dev/mikros-cli/internal/settings"
 	"github.com/mikros-dev/mikros-cli/internal/ui"
+	"github.com/mikros-dev/mikros-cli/pkg/survey"
 )
 
-func runSurvey(cfg *settings.Settings, protoFilename string) (*surveyAnswers, error) {
+// mainSurveyKey is the key of the main survey answers inside a session.
+const mainSurveyKey = "service"
+
+func runSurvey(cfg *settings.Settings, options *NewOptions) (*surveyAnswers, error) {
 	var (
 		supportedTypes = []huh.Option[string]{
 			huh.NewOption(definition.ServiceType_gRPC.String(), definition.ServiceType_gRPC.String()),
@@ -23,11 +28,19 @@ func runSurvey(cfg *settings.Settings, protoFilename string) (*surveyAnswers, er
 		}
 	)
 
-	answers, err := newSurveyAnswers(protoFilename)
+	answers, err := newSurveyAnswers(options.ProtoFilename)
 	if err != nil {
 		return nil, err
 	}
-	
+
+	if options.Session.IsReplay() {
+		if err := options.Session.Decode(mainSurveyKey, answers); err != nil {
+			return nil, err
+		}
+
+		return answers, nil
+	}
+
 	newTypes, err := plugin.GetNewServiceKinds(cfg)
 	if err != nil {
 		return nil, err
@@ -85,6 +98,14 @@ func runSurvey(cfg *settings.Settings, protoFilename string) (*surveyAnswers, er
 				huh.NewOption("OnFinish", "OnFinish"),
 			).
 			Value(&answers.Lifecycle),
+
+		huh.NewConfirm().
+			Title("Generate unit tests for the service?").
+			Value(&answers.UnitTests),
+
+		huh.NewConfirm().
+			Title("Add a Dockerfile and a compose.yaml for the service?").
+			Value(&answers.Container),
 	}
 
 	featureNames, err := plugin.GetFeaturesUINames(cfg)
@@ -112,11 +133,12 @@ func runSurvey(cfg *settings.Settings, protoFilename string) (*surveyAnswers, er
 		return nil, err
 	}
 
+	options.Session.Set(mainSurveyKey, answers)
 	return answers, nil
 }
 
 // runServiceSurvey executes the survey that a service may have implemented.
-func runServiceSurvey(cfg *settings.Settings, answers *surveyAnswers) (*client.Service, error) {
+func runServiceSurvey(cfg *settings.Settings, options *NewOptions, answers *surveyAnswers) (*client.Service, error) {
 	svc, err := plugin.GetServicePlugin(cfg, answers.Type)
 	if err != nil {
 		return nil, err
@@ -131,26 +153,24 @@ func runServiceSurvey(cfg *settings.Settings, answers *surveyAnswers) (*client.S
 		return nil, err
 	}
 
-	response, err := ui.RunFormFromSurvey(answers.Type, svcSurvey, &ui.FormOptions{
-		Theme:      cfg.GetTheme(),
-		Accessible: cfg.UI.Accessible,
+	response, d, err := runPluginSurvey(cfg, options, answers, &pluginSurvey{
+		Name:       answers.Type,
+		SessionKey: "service:" + answers.Type,
+		Survey:     svcSurvey,
+		Validate:   svc.ValidateAnswers,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	d, err := svc.ValidateAnswers(response)
-	if err != nil {
-		return nil, err
-	}
-
-	answers.SetServiceAnswers(response)
+	// Secrets are only handed to the plugin while validating answers.
+	answers.SetServiceAnswers(ui.RemoveSecrets(svcSurvey, response))
 	answers.SetServiceDefinitions(d)
 
 	return svc, nil
 }
 
-func runFeatureSurvey(cfg *settings.Settings, name string) (string, interface{}, error) {
+func runFeatureSurvey(cfg *settings.Settings, options *NewOptions, name string, answers *surveyAnswers) (string, interface{}, error) {
 	f, err := plugin.GetFeaturePlugin(cfg, name)
 	if err != nil {
 		return "", nil, err
@@ -167,23 +187,118 @@ func runFeatureSurvey(cfg *settings.Settings, name string) (string, interface{},
 		return "", nil, nil
 	}
 
-	res, err := ui.RunFormFromSurvey(name, s, &ui.FormOptions{
-		Theme:      cfg.GetTheme(),
-		Accessible: cfg.UI.Accessible,
+	_, defs, err := runPluginSurvey(cfg, options, answers, &pluginSurvey{
+		Name:       name,
+		SessionKey: "feature:" + name,
+		Survey:     s,
+		Validate:   f.ValidateAnswers,
 	})
 	if err != nil {
 		return "", nil, err
 	}
 
-	defs, err := f.ValidateAnswers(res)
+	featureName, err := f.GetName()
 	if err != nil {
 		return "", nil, err
 	}
 
-	featureName, err := f.GetName()
+	return featureName, defs, nil
+}
+
+// pluginSurvey is a survey provided by a plugin.
+type pluginSurvey struct {
+	Name       string
+	SessionKey string
+	Survey     *survey.Survey
+	Validate   func(map[string]interface{}) (map[string]interface{}, error)
+}
+
+// runPluginSurvey executes a plugin survey and validates its answers with
+// the plugin. While the plugin refuses answers pointing which questions are
+// invalid, the survey is presented again with the previous answers.
+func runPluginSurvey(
+	cfg *settings.Settings,
+	options *NewOptions,
+	answers *surveyAnswers,
+	p *pluginSurvey,
+) (map[string]interface{}, map[string]interface{}, error) {
+	if options.Session.IsReplay() {
+		return replayPluginSurvey(options, p)
+	}
+
+	directory, err := serviceDirectory(options, answers)
 	if err != nil {
-		return "", nil, err
+		return nil, nil, err
 	}
 
-	return featureName, defs, nil
+	formOptions := &ui.FormOptions{
+		Theme:      cfg.GetTheme(),
+		Accessible: cfg.UI.Accessible,
+		Context:    answers.SurveyContext(directory),
+	}
+
+	for {
+		response, err := ui.RunFormFromSurvey(p.Name, p.Survey, formOptions)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		defs, err := p.Validate(response)
+		if vErr, ok := isQuestionsError(p.Survey, err); ok {
+			formOptions.Answers = response
+			formOptions.Errors = vErr
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := ui.CheckSecrets(p.Survey, response, defs); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", p.Name, err)
+		}
+
+		// Secrets are never recorded, only references to them.
+		options.Session.Set(p.SessionKey, ui.ReferenceSecrets(p.Survey, response))
+		return response, defs, nil
+	}
+}
+
+// replayPluginSurvey validates recorded answers of a plugin survey without
+// asking anything to the user.
+func replayPluginSurvey(options *NewOptions, p *pluginSurvey) (map[string]interface{}, map[string]interface{}, error) {
+	var recorded map[string]interface{}
+	if err := options.Session.Decode(p.SessionKey, &recorded); err != nil {
+		return nil, nil, err
+	}
+
+	response, err := ui.ExpandSecrets(p.Survey, recorded)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", p.Name, err)
+	}
+
+	defs, err := p.Validate(response)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", p.Name, err)
+	}
+	if err := ui.CheckSecrets(p.Survey, response, defs); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", p.Name, err)
+	}
+
+	return response, defs, nil
+}
+
+// isQuestionsError checks if err is a validation error that points to
+// questions of the survey.
+func isQuestionsError(s *survey.Survey, err error) (*survey.ValidationError, bool) {
+	var vErr *survey.ValidationError
+	if !errors.As(err, &vErr) {
+		return nil, false
+	}
+
+	for _, f := range vErr.Fields {
+		if ui.SurveyHasQuestion(s, f.Name) {
+			return vErr, true
+		}
+	}
+
+	return nil, false
 }
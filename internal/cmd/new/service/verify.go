@@ -0,0 +1,43 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/mikros-dev/mikros-cli/internal/golang"
+	"github.com/mikros-dev/mikros-cli/internal/settings"
+	"github.com/mikros-dev/mikros-cli/internal/template"
+)
+
+// verifyService builds the service generated at the current working
+// directory, reporting compile errors with the templates that generated
+// the failing files.
+func verifyService(cfg *settings.Settings, options *NewOptions, generated []*template.GeneratedTemplate) error {
+	buildErrors, err := golang.Verify(&golang.VerifyOptions{
+		Replaces: cfg.ModuleReplaces(options.Profile),
+	})
+	if err != nil {
+		return fmt.Errorf("could not verify the service: %w", err)
+	}
+	if len(buildErrors) == 0 {
+		return nil
+	}
+
+	sources := make(map[string]string)
+	for _, gen := range generated {
+		sources[filepath.Clean(gen.Filename())] = gen.Source()
+	}
+
+	var b strings.Builder
+	b.WriteString("generated service does not build:")
+	for _, e := range buildErrors {
+		fmt.Fprintf(&b, "\n  %s:%d:%d: %s", e.Filename, e.Line, e.Column, e.Message)
+		if source, ok := sources[e.Filename]; ok {
+			fmt.Fprintf(&b, "\n    generated by %s", source)
+		}
+	}
+
+	return errors.New(b.String())
+}
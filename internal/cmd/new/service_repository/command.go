@@ -16,8 +16,10 @@ import (
 )
 
 type NewOptions struct {
-	NoVCS bool
-	Path  string
+	NoVCS         bool
+	Path          string
+	Profile       string
+	DebugTemplate bool
 }
 
 func New(cfg *settings.Settings, options *NewOptions) error {
@@ -26,14 +28,14 @@ func New(cfg *settings.Settings, options *NewOptions) error {
 		return err
 	}
 
-	if err := generateProject(options, answers); err != nil {
+	if err := generateProject(cfg, options, answers); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func generateProject(options *NewOptions, answers *surveyAnswers) error {
+func generateProject(cfg *settings.Settings, options *NewOptions, answers *surveyAnswers) error {
 	repositoryPath, err := createProjectDirectory(options, answers.RepositoryName)
 	if err != nil {
 		return err
@@ -52,7 +54,7 @@ func generateProject(options *NewOptions, answers *surveyAnswers) error {
 	}()
 
 	// Notice that, starting from here, we're inside the project directory.
-	if err := createProjectTemplates(answers, repositoryPath); err != nil {
+	if err := createProjectTemplates(cfg, options, answers, repositoryPath); err != nil {
 		return err
 	}
 
@@ -95,23 +97,23 @@ func projectBasePath(options *NewOptions, repositoryName string) (string, error)
 	return filepath.Join(options.Path, name), nil
 }
 
-func createProjectTemplates(answer *surveyAnswers, repositoryPath string) error {
+func createProjectTemplates(cfg *settings.Settings, options *NewOptions, answer *surveyAnswers, repositoryPath string) error {
 	tplCtx := &TemplateContext{
 		RepositoryName: answer.RepositoryName,
 	}
 
-	if err := createProjectRootTemplates(tplCtx); err != nil {
+	if err := createProjectRootTemplates(tplCtx, templateOptions(cfg, options, "service_repository/root")); err != nil {
 		return err
 	}
 
-	if err := createProjectScriptsTemplates(repositoryPath, tplCtx); err != nil {
+	if err := createProjectScriptsTemplates(repositoryPath, tplCtx, templateOptions(cfg, options, "service_repository/scripts")); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func createProjectRootTemplates(tplCtx *TemplateContext) error {
+func createProjectRootTemplates(tplCtx *TemplateContext, loadOptions *template.LoadOptions) error {
 	templates := []template.File{
 		{
 			Name: "Makefile",
@@ -124,9 +126,8 @@ func createProjectRootTemplates(tplCtx *TemplateContext) error {
 		},
 	}
 
-	session, err := template.NewSessionFromFiles(&template.LoadOptions{
-		TemplateNames: templates,
-	}, root_tpl.Files)
+	loadOptions.TemplateNames = templates
+	session, err := template.NewSessionFromFiles(loadOptions, root_tpl.Files)
 	if err != nil {
 		return err
 	}
@@ -138,7 +139,7 @@ func createProjectRootTemplates(tplCtx *TemplateContext) error {
 	return nil
 }
 
-func createProjectScriptsTemplates(repositoryPath string, tplCtx *TemplateContext) error {
+func createProjectScriptsTemplates(repositoryPath string, tplCtx *TemplateContext, loadOptions *template.LoadOptions) error {
 	templates := []template.File{
 		{
 			Name: "badges.sh",
@@ -169,9 +170,8 @@ func createProjectScriptsTemplates(repositoryPath string, tplCtx *TemplateContex
 		}
 	}()
 
-	session, err := template.NewSessionFromFiles(&template.LoadOptions{
-		TemplateNames: templates,
-	}, scripts_tpl.Files)
+	loadOptions.TemplateNames = templates
+	session, err := template.NewSessionFromFiles(loadOptions, scripts_tpl.Files)
 	if err != nil {
 		return err
 	}
@@ -189,6 +189,14 @@ func createProjectScriptsTemplates(repositoryPath string, tplCtx *TemplateContex
 	return nil
 }
 
+// templateOptions returns the options to load a set of templates.
+func templateOptions(cfg *settings.Settings, options *NewOptions, set string) *template.LoadOptions {
+	return &template.LoadOptions{
+		Directory: cfg.TemplatesPath(options.Profile, set),
+		Debug:     options.DebugTemplate,
+	}
+}
+
 func runTemplates(session *template.Session, context interface{}) error {
 	generated, err := session.ExecuteTemplates(context)
 	if err != nil {
@@ -0,0 +1,145 @@
+package template_pack
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mikros-dev/mikros-cli/internal/pack"
+	"github.com/mikros-dev/mikros-cli/internal/path"
+	"github.com/mikros-dev/mikros-cli/internal/settings"
+	"github.com/mikros-dev/mikros-cli/internal/template"
+)
+
+type NewOptions struct {
+	Path          string
+	Pack          string
+	Kind          string
+	DebugTemplate bool
+}
+
+// New creates a new project from a kind of project provided by an installed
+// template pack.
+func New(cfg *settings.Settings, options *NewOptions) error {
+	p, err := pack.Find(cfg, options.Pack)
+	if err != nil {
+		return err
+	}
+
+	kind, ok := p.Kind(options.Kind)
+	if !ok {
+		return fmt.Errorf("pack '%s' has no project kind '%s'", p.Name, options.Kind)
+	}
+
+	answers, err := runSurvey(cfg, kind)
+	if err != nil {
+		return err
+	}
+
+	basePath := options.Path
+	if basePath == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+
+		basePath = cwd
+	}
+
+	return Generate(basePath, p, kind, answers, options.DebugTemplate)
+}
+
+// Generate executes every template of a kind of project against context,
+// writing the generated files inside basePath.
+func Generate(basePath string, p *pack.Pack, kind *pack.Kind, context interface{}, debug bool) error {
+	for _, file := range kind.Files {
+		if err := generateFile(basePath, p, file, context, debug); err != nil {
+			return fmt.Errorf("%s: %w", file.Template, err)
+		}
+	}
+
+	return nil
+}
+
+// Answers fills the context fields of a kind of project with values,
+// using their defaults when missing, as if they were answered by the user.
+func Answers(kind *pack.Kind, values map[string]string) (map[string]string, error) {
+	answers := make(map[string]string)
+	for _, c := range kind.Context {
+		value, ok := values[c.Name]
+		if !ok {
+			value = c.Default
+		}
+		if value == "" {
+			return nil, fmt.Errorf("%s cannot be empty", c.Name)
+		}
+
+		answers[c.Name] = value
+	}
+
+	for name := range values {
+		if _, ok := answers[name]; !ok {
+			return nil, fmt.Errorf("kind '%s' has no context field '%s'", kind.Name, name)
+		}
+	}
+
+	return answers, nil
+}
+
+func generateFile(basePath string, p *pack.Pack, file *pack.File, context interface{}, debug bool) error {
+	output, err := template.ParseBlock(file.Output, nil, context)
+	if err != nil {
+		return err
+	}
+	output = strings.TrimSpace(output)
+	if _, err := path.JoinLocal(basePath, output); err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(filepath.Join(p.Path(), filepath.FromSlash(file.Template)))
+	if err != nil {
+		return err
+	}
+
+	name := strings.TrimSuffix(file.Template, filepath.Ext(file.Template))
+	session, err := template.NewSessionFromData(&template.LoadOptions{
+		TemplateNames: []template.File{
+			{
+				Name:   name,
+				Output: output,
+				When:   file.When,
+			},
+		},
+		Origin: fmt.Sprintf("pack '%s'", p.Name),
+		Debug:  debug,
+	}, []*template.Data{
+		{
+			FileName: file.Template,
+			Content:  content,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	generated, err := session.ExecuteTemplates(context)
+	if err != nil {
+		return err
+	}
+
+	for _, gen := range generated {
+		filename, err := path.JoinLocal(basePath, gen.Filename())
+		if err != nil {
+			return err
+		}
+		if _, err := path.CreatePath(filepath.Dir(filename)); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filename, gen.Content(), 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package template_pack
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/huh"
+
+	"github.com/mikros-dev/mikros-cli/internal/pack"
+	"github.com/mikros-dev/mikros-cli/internal/settings"
+	"github.com/mikros-dev/mikros-cli/internal/ui"
+)
+
+// runSurvey asks the user every context field required by the kind of
+// project.
+func runSurvey(cfg *settings.Settings, kind *pack.Kind) (map[string]string, error) {
+	if len(kind.Context) == 0 {
+		return map[string]string{}, nil
+	}
+
+	var (
+		values    = make([]string, len(kind.Context))
+		questions = make([]huh.Field, len(kind.Context))
+	)
+
+	for i, c := range kind.Context {
+		title := c.Message
+		if title == "" {
+			title = fmt.Sprintf("Enter the %s:", c.Name)
+		}
+
+		values[i] = c.Default
+		questions[i] = huh.NewInput().
+			Title(title).
+			Value(&values[i]).
+			Validate(ui.IsEmpty(fmt.Sprintf("%s cannot be empty", c.Name)))
+	}
+
+	form := huh.NewForm(huh.NewGroup(questions...)).
+		WithAccessible(cfg.UI.Accessible).
+		WithTheme(cfg.GetTheme())
+
+	if err := form.Run(); err != nil {
+		return nil, err
+	}
+
+	answers := make(map[string]string)
+	for i, c := range kind.Context {
+		answers[c.Name] = values[i]
+	}
+
+	return answers, nil
+}
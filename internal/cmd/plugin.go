@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mikros-dev/mikros-cli/internal/settings"
+)
+
+var (
+	pluginCmd = &cobra.Command{
+		Use:   "plugin",
+		Short: "Inspect mikros CLI plugins",
+		Long: `plugin helps plugin developers checking their plugins before
+installing them.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := cmd.Help(); err != nil {
+				fmt.Println("plugin:", err)
+				return
+			}
+		},
+	}
+)
+
+func pluginCmdInit(cfg *settings.Settings) {
+	pluginValidateCmdInit(cfg)
+	pluginSchemaCmdInit(cfg)
+	rootCmd.AddCommand(pluginCmd)
+}
@@ -0,0 +1,84 @@
+package plugin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	plugins "github.com/mikros-dev/mikros-cli/internal/plugin"
+	"github.com/mikros-dev/mikros-cli/internal/settings"
+	"github.com/mikros-dev/mikros-cli/pkg/survey"
+)
+
+type SchemaOptions struct {
+	// AnswersFilename is an optional JSON file with answers to be validated
+	// against the schema instead of printing it.
+	AnswersFilename string
+}
+
+// Schema prints the JSON Schema of the answers produced by a plugin survey.
+func Schema(cfg *settings.Settings, name string, options *SchemaOptions) error {
+	schema, err := pluginSchema(cfg, name)
+	if err != nil {
+		return err
+	}
+
+	if options.AnswersFilename != "" {
+		return validateAnswersFile(schema, options.AnswersFilename)
+	}
+
+	b, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(b))
+	return nil
+}
+
+func pluginSchema(cfg *settings.Settings, name string) (*survey.Schema, error) {
+	p, err := plugins.FindSurveyPlugin(cfg, name)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := p.GetSurvey()
+	if err != nil {
+		return nil, err
+	}
+	if s == nil {
+		return nil, errors.New("plugin has no survey")
+	}
+
+	surveyName, err := p.SurveyName()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.JSONSchema(surveyName), nil
+}
+
+func validateAnswersFile(schema *survey.Schema, filename string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+
+	var answers interface{}
+	if err := json.Unmarshal(data, &answers); err != nil {
+		return err
+	}
+
+	if err := schema.Validate(answers); err != nil {
+		for _, problem := range strings.Split(err.Error(), "\n") {
+			fmt.Println("✗", problem)
+		}
+
+		return errors.New("invalid answers")
+	}
+
+	fmt.Println("✅ Answers are valid")
+	return nil
+}
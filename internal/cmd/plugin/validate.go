@@ -0,0 +1,39 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	plugins "github.com/mikros-dev/mikros-cli/internal/plugin"
+	"github.com/mikros-dev/mikros-cli/internal/settings"
+)
+
+// Validate checks the structure of a plugin survey, printing every problem
+// found.
+func Validate(cfg *settings.Settings, name string) error {
+	p, err := plugins.FindSurveyPlugin(cfg, name)
+	if err != nil {
+		return err
+	}
+
+	s, err := p.GetSurvey()
+	if err != nil {
+		return err
+	}
+	if s == nil {
+		fmt.Println("Plugin has no survey")
+		return nil
+	}
+
+	if err := s.Validate(); err != nil {
+		for _, problem := range strings.Split(err.Error(), "\n") {
+			fmt.Println("✗", problem)
+		}
+
+		return errors.New("invalid plugin survey")
+	}
+
+	fmt.Println("✅ Plugin survey is valid")
+	return nil
+}
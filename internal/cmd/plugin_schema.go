@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/mikros-dev/mikros-cli/internal/cmd/plugin"
+	"github.com/mikros-dev/mikros-cli/internal/settings"
+)
+
+var (
+	pluginSchemaCmd = &cobra.Command{
+		Use:   "schema <plugin>",
+		Short: "Export the JSON Schema of a plugin survey answers",
+		Long: `schema prints a JSON Schema describing the answers that a plugin
+survey produces, including its loops and follow-up surveys. It
+can be used to document the plugin, to validate answers files or
+to drive IDE completion.
+
+The plugin can be the path of its executable, a service kind or
+a feature name installed in the configured plugins paths.`,
+		Args: cobra.ExactArgs(1),
+	}
+)
+
+func pluginSchemaCmdInit(cfg *settings.Settings) {
+	setPluginSchemaCmdFlags()
+	pluginSchemaCmd.Run = func(cmd *cobra.Command, args []string) {
+		options := &plugin.SchemaOptions{
+			AnswersFilename: viper.GetString("plugin-schema-validate"),
+		}
+
+		if err := plugin.Schema(cfg, args[0], options); err != nil {
+			fmt.Println("plugin:", err)
+			os.Exit(1)
+		}
+	}
+
+	pluginCmd.AddCommand(pluginSchemaCmd)
+}
+
+func setPluginSchemaCmdFlags() {
+	// validate option
+	pluginSchemaCmd.Flags().String("validate", "", "Validates a JSON answers file against the schema instead of printing it.")
+	_ = viper.BindPFlag("plugin-schema-validate", pluginSchemaCmd.Flags().Lookup("validate"))
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mikros-dev/mikros-cli/internal/cmd/plugin"
+	"github.com/mikros-dev/mikros-cli/internal/settings"
+)
+
+var (
+	pluginValidateCmd = &cobra.Command{
+		Use:   "validate <plugin>",
+		Short: "Check a plugin survey structure",
+		Long: `validate checks the survey of a plugin, reporting problems like
+duplicated question names, follow-up surveys without conditions
+or select defaults that are not one of its options.
+
+The plugin can be the path of its executable, a service kind or
+a feature name installed in the configured plugins paths.`,
+		Args: cobra.ExactArgs(1),
+	}
+)
+
+func pluginValidateCmdInit(cfg *settings.Settings) {
+	pluginValidateCmd.Run = func(cmd *cobra.Command, args []string) {
+		if err := plugin.Validate(cfg, args[0]); err != nil {
+			fmt.Println("plugin:", err)
+			os.Exit(1)
+		}
+	}
+
+	pluginCmd.AddCommand(pluginValidateCmd)
+}
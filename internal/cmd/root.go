@@ -39,4 +39,8 @@ func Execute() {
 func loadCommands(cfg *settings.Settings) {
 	newCmdInit(cfg)
 	configCmdInit()
+	pluginCmdInit(cfg)
+	templateCmdInit(cfg)
+	addCmdInit(cfg)
+	deployCmdInit(cfg)
 }
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mikros-dev/mikros-cli/internal/settings"
+)
+
+var (
+	templateCmd = &cobra.Command{
+		Use:   "template",
+		Short: "Manage and test template packs",
+		Long: `template manages template packs, versioned sets of templates that
+provide new kinds of projects to the new command, and tests them, or
+the customized built-in templates, against golden files.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := cmd.Help(); err != nil {
+				fmt.Println("template:", err)
+				return
+			}
+		},
+	}
+)
+
+func templateCmdInit(cfg *settings.Settings) {
+	templateListCmdInit(cfg)
+	templateInstallCmdInit(cfg)
+	templateRemoveCmdInit(cfg)
+	templateTestCmdInit(cfg)
+	rootCmd.AddCommand(templateCmd)
+}
@@ -0,0 +1,612 @@
+package template
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	container_tpl "github.com/mikros-dev/mikros-cli/internal/assets/templates/container"
+	deploy_tpl "github.com/mikros-dev/mikros-cli/internal/assets/templates/deploy"
+	protobuf_module_tpl "github.com/mikros-dev/mikros-cli/internal/assets/templates/protobuf_module"
+	proto_tpl "github.com/mikros-dev/mikros-cli/internal/assets/templates/protobuf_repository/proto"
+	root_tpl "github.com/mikros-dev/mikros-cli/internal/assets/templates/protobuf_repository/root"
+	scripts_tpl "github.com/mikros-dev/mikros-cli/internal/assets/templates/protobuf_repository/scripts"
+	service_tpl "github.com/mikros-dev/mikros-cli/internal/assets/templates/service"
+	service_root_tpl "github.com/mikros-dev/mikros-cli/internal/assets/templates/service_repository/root"
+	service_scripts_tpl "github.com/mikros-dev/mikros-cli/internal/assets/templates/service_repository/scripts"
+	"github.com/mikros-dev/mikros-cli/internal/cmd/deploy/manifests"
+	"github.com/mikros-dev/mikros-cli/internal/cmd/new/container"
+	"github.com/mikros-dev/mikros-cli/internal/cmd/new/protobuf_module"
+	"github.com/mikros-dev/mikros-cli/internal/cmd/new/protobuf_repository"
+	"github.com/mikros-dev/mikros-cli/internal/cmd/new/service"
+	"github.com/mikros-dev/mikros-cli/internal/cmd/new/service_repository"
+	"github.com/mikros-dev/mikros-cli/internal/cmd/new/template_pack"
+	"github.com/mikros-dev/mikros-cli/internal/pack"
+	"github.com/mikros-dev/mikros-cli/internal/path"
+	"github.com/mikros-dev/mikros-cli/internal/session"
+	"github.com/mikros-dev/mikros-cli/internal/settings"
+	"github.com/mikros-dev/mikros-cli/internal/template"
+)
+
+const (
+	testsDirectory   = "tests"
+	goldenDirectory  = "golden"
+	contextFilename  = "context.json"
+	answersFilename  = "answers.json"
+	sessionFilename  = "session.json"
+	protoFilename    = "service.proto"
+	maxReportedDiffs = 10
+)
+
+type TestOptions struct {
+	Update        bool
+	DebugTemplate bool
+}
+
+// templateSet is a set of built-in templates that can be overridden.
+type templateSet struct {
+	name  string
+	files embed.FS
+
+	// templates are the templates executed by the generator of the set,
+	// when they're not simply named after their files.
+	templates []template.File
+
+	// context returns the type of context the generator of the set gives
+	// to its templates, where fixtures are decoded into.
+	context func() interface{}
+
+	// executable tells if the generator of the set makes its files
+	// executable.
+	executable bool
+
+	// replay returns the context of a test case from a replayed session,
+	// for sets whose generator builds it from survey answers.
+	replay func(cfg *settings.Settings, sess *session.Session, caseDir string) (interface{}, error)
+}
+
+var (
+	templateSets = []*templateSet{
+		{
+			name:      "service",
+			files:     service_tpl.Files,
+			templates: service.TemplateNames(),
+			context:   func() interface{} { return &service.TemplateContext{} },
+			replay:    replayServiceContext,
+		},
+		{
+			name:      "container",
+			files:     container_tpl.Files,
+			templates: container.TemplateNames(),
+			context:   func() interface{} { return &container.TemplateContext{} },
+		},
+		{
+			name:      "deploy",
+			files:     deploy_tpl.Files,
+			templates: manifests.TemplateNames(),
+			context:   func() interface{} { return &manifests.TemplateContext{} },
+		},
+		{
+			name:    "protobuf_module",
+			files:   protobuf_module_tpl.Files,
+			context: func() interface{} { return &protobuf_module.Context{} },
+		},
+		{
+			name:    "protobuf_repository/root",
+			files:   root_tpl.Files,
+			context: func() interface{} { return &protobuf_repository.TemplateContext{} },
+		},
+		{
+			name:       "protobuf_repository/scripts",
+			files:      scripts_tpl.Files,
+			context:    func() interface{} { return &protobuf_repository.TemplateContext{} },
+			executable: true,
+		},
+		{
+			name:    "protobuf_repository/proto",
+			files:   proto_tpl.Files,
+			context: func() interface{} { return &protobuf_repository.TemplateContext{} },
+		},
+		{
+			name:    "service_repository/root",
+			files:   service_root_tpl.Files,
+			context: func() interface{} { return &service_repository.TemplateContext{} },
+		},
+		{
+			name:       "service_repository/scripts",
+			files:      service_scripts_tpl.Files,
+			context:    func() interface{} { return &service_repository.TemplateContext{} },
+			executable: true,
+		},
+	}
+)
+
+// testSuite holds the test cases of a pack kind or of a template set.
+type testSuite struct {
+	name string
+	dir  string
+
+	// render generates the files of the test case located at caseDir
+	// inside outputPath.
+	render func(outputPath, caseDir string) error
+}
+
+// Test renders the templates of a template pack, or of a directory
+// overriding the built-in templates, against the fixtures found inside its
+// tests directory, comparing the output with their golden files.
+func Test(cfg *settings.Settings, dir string, options *TestOptions) error {
+	suites, err := loadSuites(cfg, dir, options)
+	if err != nil {
+		return err
+	}
+
+	var (
+		total  int
+		failed int
+	)
+
+	for _, suite := range suites {
+		cases, err := os.ReadDir(suite.dir)
+		if err != nil {
+			return err
+		}
+
+		for _, c := range cases {
+			if !c.IsDir() {
+				continue
+			}
+
+			total++
+			name := suite.name + "/" + c.Name()
+			problems, err := runTestCase(suite, filepath.Join(suite.dir, c.Name()), options)
+			if err != nil {
+				problems = append(problems, err.Error())
+			}
+			if len(problems) > 0 {
+				failed++
+				fmt.Printf("❌ %s\n", name)
+				for _, p := range problems {
+					fmt.Printf("    %s\n", strings.ReplaceAll(p, "\n", "\n    "))
+				}
+				continue
+			}
+
+			if options.Update {
+				fmt.Printf("✅ %s (updated)\n", name)
+				continue
+			}
+
+			fmt.Printf("✅ %s\n", name)
+		}
+	}
+
+	if total == 0 {
+		return fmt.Errorf("no test cases found inside '%s'", filepath.Join(dir, testsDirectory))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d tests failed", failed, total)
+	}
+
+	return nil
+}
+
+func loadSuites(cfg *settings.Settings, dir string, options *TestOptions) ([]*testSuite, error) {
+	if _, err := os.Stat(filepath.Join(dir, pack.ManifestFilename)); err == nil {
+		p, err := pack.Load(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		return packSuites(p, options), nil
+	}
+
+	return setSuites(cfg, dir, options), nil
+}
+
+// packSuites gives a suite for every kind of the pack with test cases,
+// located at tests/<kind>/<case>.
+func packSuites(p *pack.Pack, options *TestOptions) []*testSuite {
+	var suites []*testSuite
+	for _, kind := range p.Kinds {
+		dir := filepath.Join(p.Path(), testsDirectory, kind.Name)
+		if !path.FindPath(dir) {
+			continue
+		}
+
+		suites = append(suites, &testSuite{
+			name: kind.Name,
+			dir:  dir,
+			render: func(outputPath, caseDir string) error {
+				context, err := packContext(kind, caseDir)
+				if err != nil {
+					return err
+				}
+
+				return template_pack.Generate(outputPath, p, kind, context, options.DebugTemplate)
+			},
+		})
+	}
+
+	return suites
+}
+
+// packContext reads the context of a test case, either as it is given to
+// templates or as the answers given to the kind context fields.
+func packContext(kind *pack.Kind, caseDir string) (interface{}, error) {
+	var values map[string]string
+	if err := readJSON(filepath.Join(caseDir, answersFilename), &values); err == nil {
+		return template_pack.Answers(kind, values)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	var context map[string]interface{}
+	if err := readContext(caseDir, &context); err != nil {
+		return nil, err
+	}
+
+	return context, nil
+}
+
+// setSuites gives a suite for every template set with test cases, located
+// at tests/<set>/<case>, using the templates found at <set>.
+func setSuites(cfg *settings.Settings, dir string, options *TestOptions) []*testSuite {
+	var suites []*testSuite
+	for _, set := range templateSets {
+		testsDir := filepath.Join(dir, testsDirectory, filepath.FromSlash(set.name))
+		if !path.FindPath(testsDir) {
+			continue
+		}
+
+		suites = append(suites, &testSuite{
+			name: set.name,
+			dir:  testsDir,
+			render: func(outputPath, caseDir string) error {
+				context, err := setContext(cfg, set, caseDir)
+				if err != nil {
+					return err
+				}
+
+				return renderSet(outputPath, filepath.Join(dir, filepath.FromSlash(set.name)), set, context, options.DebugTemplate)
+			},
+		})
+	}
+
+	return suites
+}
+
+// setContext reads the context of a test case, either as it is given to
+// templates or, for sets supporting it, from a recorded session or the
+// answers of the survey of their generator.
+func setContext(cfg *settings.Settings, set *templateSet, caseDir string) (interface{}, error) {
+	if set.replay != nil {
+		sess, err := readSession(caseDir)
+		if err != nil {
+			return nil, err
+		}
+		if sess != nil {
+			return set.replay(cfg, sess, caseDir)
+		}
+	}
+
+	context := set.context()
+	if err := readContext(caseDir, context); err != nil {
+		return nil, err
+	}
+
+	return context, nil
+}
+
+// readSession loads the session of a test case, from a session file or
+// from the answers of the main survey, if it has any.
+func readSession(caseDir string) (*session.Session, error) {
+	filename := filepath.Join(caseDir, sessionFilename)
+	if path.FindPath(filename) {
+		return session.Load(filename)
+	}
+
+	var answers map[string]interface{}
+	if err := readJSON(filepath.Join(caseDir, answersFilename), &answers); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return service.AnswersSession(answers), nil
+}
+
+// replayServiceContext builds the context of the service templates from
+// a session, using the test case protobuf file, when present.
+func replayServiceContext(cfg *settings.Settings, sess *session.Session, caseDir string) (interface{}, error) {
+	if sess.Project != "service-template" {
+		return nil, fmt.Errorf("%s: session is not of a service template", sessionFilename)
+	}
+
+	var protoFile string
+	if filename := filepath.Join(caseDir, protoFilename); path.FindPath(filename) {
+		protoFile = filename
+	}
+
+	return service.ReplayTemplateContext(cfg, sess, protoFile)
+}
+
+// renderSet executes the templates of a set, overridden by the ones found
+// inside directory, like its generator does.
+func renderSet(outputPath, directory string, set *templateSet, context interface{}, debug bool) error {
+	templates := set.templates
+	if templates == nil {
+		entries, err := set.files.ReadDir(".")
+		if err != nil {
+			return err
+		}
+
+		for _, e := range entries {
+			if strings.HasPrefix(e.Name(), "_") {
+				continue
+			}
+
+			templates = append(templates, template.File{
+				Name: strings.TrimSuffix(e.Name(), filepath.Ext(e.Name())),
+			})
+		}
+	}
+
+	session, err := template.NewSessionFromFiles(&template.LoadOptions{
+		TemplateNames: templates,
+		Directory:     directory,
+		Debug:         debug,
+	}, set.files)
+	if err != nil {
+		return err
+	}
+
+	generated, err := session.ExecuteTemplates(context)
+	if err != nil {
+		return err
+	}
+
+	for _, gen := range generated {
+		filename, err := path.JoinLocal(outputPath, gen.Filename())
+		if err != nil {
+			return err
+		}
+		if _, err := path.CreatePath(filepath.Dir(filename)); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filename, gen.Content(), 0644); err != nil {
+			return err
+		}
+
+		if mode := gen.Mode(); mode != 0 {
+			if err := os.Chmod(filename, mode); err != nil {
+				return err
+			}
+		}
+		if set.executable {
+			if err := path.SetExecutablePath(filename); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// readContext decodes the context of a test case into out.
+func readContext(caseDir string, out interface{}) error {
+	if err := readJSON(filepath.Join(caseDir, contextFilename), out); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("test case has no %s file", contextFilename)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func readJSON(filename string, out interface{}) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("%s: %w", filepath.Base(filename), err)
+	}
+
+	return nil
+}
+
+// runTestCase renders a test case into a temporary directory, comparing it
+// with the golden files or replacing them when updating.
+func runTestCase(suite *testSuite, caseDir string, options *TestOptions) ([]string, error) {
+	outputPath, err := os.MkdirTemp("", "mikros-template-test-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = os.RemoveAll(outputPath)
+	}()
+
+	if err := suite.render(outputPath, caseDir); err != nil {
+		return nil, err
+	}
+
+	goldenPath := filepath.Join(caseDir, goldenDirectory)
+	if options.Update {
+		return nil, updateGolden(outputPath, goldenPath)
+	}
+	if !path.FindPath(goldenPath) {
+		return nil, errors.New("no golden files, run with --update to create them")
+	}
+
+	return compareGolden(outputPath, goldenPath)
+}
+
+// updateGolden replaces the golden files with the generated ones.
+func updateGolden(outputPath, goldenPath string) error {
+	if err := os.RemoveAll(goldenPath); err != nil {
+		return err
+	}
+
+	return os.CopyFS(goldenPath, os.DirFS(outputPath))
+}
+
+// compareGolden tells the differences between the generated files and the
+// golden ones.
+func compareGolden(outputPath, goldenPath string) ([]string, error) {
+	generated, err := listFiles(outputPath)
+	if err != nil {
+		return nil, err
+	}
+	golden, err := listFiles(goldenPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []string
+	for _, name := range golden {
+		if !slices.Contains(generated, name) {
+			problems = append(problems, fmt.Sprintf("%s: not generated", name))
+		}
+	}
+
+	for _, name := range generated {
+		if !slices.Contains(golden, name) {
+			problems = append(problems, fmt.Sprintf("%s: not expected", name))
+			continue
+		}
+
+		got, err := os.ReadFile(filepath.Join(outputPath, name))
+		if err != nil {
+			return nil, err
+		}
+		want, err := os.ReadFile(filepath.Join(goldenPath, name))
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(got, want) {
+			problems = append(problems, fmt.Sprintf("%s: differs from golden file\n%s", name, diff(string(want), string(got))))
+		}
+
+		problem, err := compareModes(name, outputPath, goldenPath)
+		if err != nil {
+			return nil, err
+		}
+		if problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+
+	return problems, nil
+}
+
+// compareModes tells if a generated file and its golden file differ on
+// being executable, the only permission kept by version control systems.
+func compareModes(name, outputPath, goldenPath string) (string, error) {
+	got, err := os.Stat(filepath.Join(outputPath, name))
+	if err != nil {
+		return "", err
+	}
+	want, err := os.Stat(filepath.Join(goldenPath, name))
+	if err != nil {
+		return "", err
+	}
+
+	if isExecutable(got.Mode()) == isExecutable(want.Mode()) {
+		return "", nil
+	}
+
+	return fmt.Sprintf("%s: mode %s differs from golden file mode %s", name, got.Mode().Perm(), want.Mode().Perm()), nil
+}
+
+func isExecutable(mode fs.FileMode) bool {
+	return mode.Perm()&0111 != 0
+}
+
+// listFiles returns the files inside dir, with slash separated paths
+// relative to it.
+func listFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+
+	slices.Sort(files)
+	return files, err
+}
+
+// diff shows the lines removed from the golden content and the ones
+// added by the generated one, following their longest common subsequence,
+// so a single inserted or removed line is reported alone.
+func diff(want, got string) string {
+	var (
+		wantLines = strings.Split(want, "\n")
+		gotLines  = strings.Split(got, "\n")
+		lines     []string
+	)
+
+	// lcs[i][j] is the length of the longest common subsequence of
+	// wantLines[i:] and gotLines[j:].
+	lcs := make([][]int, len(wantLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(gotLines)+1)
+	}
+	for i := len(wantLines) - 1; i >= 0; i-- {
+		for j := len(gotLines) - 1; j >= 0; j-- {
+			if wantLines[i] == gotLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+				continue
+			}
+
+			lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(wantLines) || j < len(gotLines) {
+		if i < len(wantLines) && j < len(gotLines) && wantLines[i] == gotLines[j] {
+			i++
+			j++
+			continue
+		}
+
+		if len(lines) == maxReportedDiffs*2 {
+			lines = append(lines, "...")
+			break
+		}
+
+		if j == len(gotLines) || (i < len(wantLines) && lcs[i+1][j] >= lcs[i][j+1]) {
+			lines = append(lines, fmt.Sprintf("%4d - %s", i+1, wantLines[i]))
+			i++
+			continue
+		}
+
+		lines = append(lines, fmt.Sprintf("%4d + %s", j+1, gotLines[j]))
+		j++
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,65 @@
+package template
+
+import (
+	"fmt"
+
+	"github.com/mikros-dev/mikros-cli/internal/pack"
+	"github.com/mikros-dev/mikros-cli/internal/settings"
+)
+
+type InstallOptions struct {
+	Ref string
+}
+
+// List prints the installed template packs and their kinds of projects.
+func List(cfg *settings.Settings) error {
+	packs, err := pack.List(cfg)
+	if err != nil {
+		return err
+	}
+	if len(packs) == 0 {
+		fmt.Println("No template packs installed")
+		return nil
+	}
+
+	for _, p := range packs {
+		fmt.Printf("%s %s", p.Name, p.Version)
+		if p.Description != "" {
+			fmt.Printf(" - %s", p.Description)
+		}
+		fmt.Println()
+
+		for _, k := range p.Kinds {
+			fmt.Printf("  • %s", k.Name)
+			if k.Description != "" {
+				fmt.Printf(": %s", k.Description)
+			}
+			fmt.Println()
+		}
+	}
+
+	return nil
+}
+
+// Install installs a template pack from a directory or a git repository.
+func Install(cfg *settings.Settings, source string, options *InstallOptions) error {
+	p, err := pack.Install(cfg, source, &pack.InstallOptions{
+		Ref: options.Ref,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Template pack %s %s installed\n", p.Name, p.Version)
+	return nil
+}
+
+// Remove uninstalls a template pack.
+func Remove(cfg *settings.Settings, name string) error {
+	if err := pack.Remove(cfg, name); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Template pack %s removed\n", name)
+	return nil
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/mikros-dev/mikros-cli/internal/cmd/template"
+	"github.com/mikros-dev/mikros-cli/internal/settings"
+)
+
+var (
+	templateTestCmd = &cobra.Command{
+		Use:   "test <dir>",
+		Short: "Test templates against golden files",
+		Long: `test renders the templates of a template pack, or of a directory
+overriding the built-in templates, against the fixtures found inside
+its tests directory and compares the output with their golden files.`,
+		Args: cobra.ExactArgs(1),
+	}
+)
+
+func templateTestCmdInit(cfg *settings.Settings) {
+	setTemplateTestCmdFlags()
+	templateTestCmd.Run = func(cmd *cobra.Command, args []string) {
+		options := &template.TestOptions{
+			Update:        viper.GetBool("template-test-update"),
+			DebugTemplate: viper.GetBool("template-test-debug-template"),
+		}
+
+		if err := template.Test(cfg, args[0], options); err != nil {
+			fmt.Println("template:", err)
+			os.Exit(1)
+		}
+	}
+
+	templateCmd.AddCommand(templateTestCmd)
+}
+
+func setTemplateTestCmdFlags() {
+	// update option
+	templateTestCmd.Flags().Bool("update", false, "Replaces the golden files with the generated ones.")
+	_ = viper.BindPFlag("template-test-update", templateTestCmd.Flags().Lookup("update"))
+
+	// debug-template option
+	templateTestCmd.Flags().Bool("debug-template", false, "Shows the context passed to templates when they fail.")
+	_ = viper.BindPFlag("template-test-debug-template", templateTestCmd.Flags().Lookup("debug-template"))
+}
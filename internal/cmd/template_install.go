@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/mikros-dev/mikros-cli/internal/cmd/template"
+	"github.com/mikros-dev/mikros-cli/internal/settings"
+)
+
+var (
+	templateInstallCmd = &cobra.Command{
+		Use:   "install <source>",
+		Short: "Install a template pack",
+		Long: `install installs a template pack from a local directory or a git
+repository. A pack already installed with the same name is
+replaced, allowing it to be upgraded.`,
+		Args: cobra.ExactArgs(1),
+	}
+)
+
+func templateInstallCmdInit(cfg *settings.Settings) {
+	setTemplateInstallCmdFlags()
+	templateInstallCmd.Run = func(cmd *cobra.Command, args []string) {
+		options := &template.InstallOptions{
+			Ref: viper.GetString("template-install-ref"),
+		}
+
+		if err := template.Install(cfg, args[0], options); err != nil {
+			fmt.Println("template:", err)
+			return
+		}
+	}
+
+	templateCmd.AddCommand(templateInstallCmd)
+}
+
+func setTemplateInstallCmdFlags() {
+	// ref option
+	templateInstallCmd.Flags().String("ref", "", "Sets the branch or tag to install from a git repository.")
+	_ = viper.BindPFlag("template-install-ref", templateInstallCmd.Flags().Lookup("ref"))
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mikros-dev/mikros-cli/internal/cmd/template"
+	"github.com/mikros-dev/mikros-cli/internal/settings"
+)
+
+var (
+	templateListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List installed template packs",
+		Long:  "list shows the installed template packs and their kinds of projects.",
+		Args:  cobra.NoArgs,
+	}
+)
+
+func templateListCmdInit(cfg *settings.Settings) {
+	templateListCmd.Run = func(cmd *cobra.Command, args []string) {
+		if err := template.List(cfg); err != nil {
+			fmt.Println("template:", err)
+			return
+		}
+	}
+
+	templateCmd.AddCommand(templateListCmd)
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mikros-dev/mikros-cli/internal/cmd/template"
+	"github.com/mikros-dev/mikros-cli/internal/settings"
+)
+
+var (
+	templateRemoveCmd = &cobra.Command{
+		Use:   "remove <pack>",
+		Short: "Remove an installed template pack",
+		Long:  "remove uninstalls a template pack by its name.",
+		Args:  cobra.ExactArgs(1),
+	}
+)
+
+func templateRemoveCmdInit(cfg *settings.Settings) {
+	templateRemoveCmd.Run = func(cmd *cobra.Command, args []string) {
+		if err := template.Remove(cfg, args[0]); err != nil {
+			fmt.Println("template:", err)
+			return
+		}
+	}
+
+	templateCmd.AddCommand(templateRemoveCmd)
+}
@@ -5,9 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/BurntSushi/toml"
+	"github.com/iancoleman/strcase"
 
 	"github.com/mikros-dev/mikros/components/definition"
 )
@@ -157,3 +163,139 @@ func appendFeatureDefinitions(filename string, defs interface{}) error {
 
 	return nil
 }
+
+var (
+	// envReferenceRegex matches references to environment variables inside
+	// the service definitions, like "${DATABASE_PASSWORD}".
+	envReferenceRegex = regexp.MustCompile(`\$\{([A-Z0-9_]+)\}`)
+
+	// defaultPorts are the ports, and the environment variables setting
+	// them, used by service types when their ports are not set.
+	defaultPorts = map[string]*ServicePort{
+		definition.ServiceType_gRPC.String(): {Env: "MIKROS_GRPC_PORT", Port: 7070},
+		definition.ServiceType_HTTP.String(): {Env: "MIKROS_HTTP_PORT", Port: 8080},
+	}
+)
+
+// File is the content of a 'service.toml' file.
+type File struct {
+	Definitions *definition.Definitions
+
+	// Features holds the settings of every feature, by its name, since
+	// Definitions only keeps the ones of features registered into it.
+	Features map[string]map[string]interface{}
+
+	// Content is the file content, as it is.
+	Content []byte
+}
+
+// ServicePort is a port where a service listens to.
+type ServicePort struct {
+	// Name is the service type using the port, like "grpc".
+	Name string
+
+	// Env is the environment variable that sets the port to the service.
+	Env  string
+	Port int32
+}
+
+// Read reads the 'service.toml' file of the service located at path.
+func Read(path string) (*File, error) {
+	filename := filepath.Join(path, "service.toml")
+
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	defs, err := definition.New()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := toml.Decode(string(content), defs); err != nil {
+		return nil, err
+	}
+
+	var data map[string]interface{}
+	if _, err := toml.Decode(string(content), &data); err != nil {
+		return nil, err
+	}
+
+	file := &File{
+		Definitions: defs,
+		Features:    make(map[string]map[string]interface{}),
+		Content:     content,
+	}
+	if features, ok := data["features"].(map[string]interface{}); ok {
+		for name, settings := range features {
+			if s, ok := settings.(map[string]interface{}); ok {
+				file.Features[name] = s
+			}
+		}
+	}
+
+	return file, nil
+}
+
+// ServiceName returns the service name usable as file, container and
+// resource names. Fully qualified names, like "github.com/org/svc", only
+// have their last element used.
+func (f *File) ServiceName() string {
+	return strcase.ToKebab(path.Base(f.Definitions.Name))
+}
+
+// Ports returns the ports where the service listens to, by its types.
+// Scripts, native services and plugin services don't listen to ports by
+// themselves.
+func (f *File) Ports() []*ServicePort {
+	var ports []*ServicePort
+	for _, t := range f.Definitions.Types {
+		name, port, _ := strings.Cut(t, ":")
+
+		p, ok := defaultPorts[name]
+		if !ok {
+			continue
+		}
+
+		servicePort := &ServicePort{
+			Name: name,
+			Env:  p.Env,
+			Port: p.Port,
+		}
+		if n, err := strconv.ParseInt(port, 10, 32); err == nil {
+			servicePort.Port = int32(n)
+		}
+
+		ports = append(ports, servicePort)
+	}
+
+	return ports
+}
+
+// Envs returns the environment variables that the service needs, declared
+// by it or referenced by its features and services settings.
+func (f *File) Envs() []string {
+	envs := slices.Clone(f.Definitions.Envs)
+	for _, m := range envReferenceRegex.FindAllStringSubmatch(string(f.Content), -1) {
+		envs = append(envs, m[1])
+	}
+
+	slices.Sort(envs)
+	return slices.Compact(envs)
+}
+
+// EnabledFeatures returns the names of the features that are not disabled
+// by their settings.
+func (f *File) EnabledFeatures() []string {
+	var features []string
+	for name, settings := range f.Features {
+		if enabled, ok := settings["enabled"].(bool); ok && !enabled {
+			continue
+		}
+
+		features = append(features, name)
+	}
+
+	slices.Sort(features)
+	return features
+}
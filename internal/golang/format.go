@@ -0,0 +1,136 @@
+package golang
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// Format formats Go source code the same way gofmt does, also removing
+// unused imports. Missing imports are never added, so they are reported
+// when the code is built. The filename is used to report syntax errors.
+func Format(filename string, src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	removeUnusedImports(fset, file)
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// removeUnusedImports removes the imports whose names are not referenced
+// by the file. Imports without an explicit name are named after their
+// path, so they're only removed when every package referenced by the file
+// is matched by an import, since their package may have another name.
+func removeUnusedImports(fset *token.FileSet, file *ast.File) {
+	var (
+		used     = referencedPackages(file)
+		names    = make(map[*ast.ImportSpec]string)
+		imported = make(map[string]bool)
+	)
+
+	for _, spec := range file.Imports {
+		name := importName(spec)
+		names[spec] = name
+		imported[name] = true
+	}
+
+	unmatched := false
+	for name := range used {
+		if !imported[name] {
+			unmatched = true
+			break
+		}
+	}
+
+	// Deleting imports changes file.Imports.
+	for _, spec := range slices.Clone(file.Imports) {
+		name := names[spec]
+		if name == "_" || name == "." || used[name] {
+			continue
+		}
+		if spec.Name == nil && unmatched {
+			continue
+		}
+
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		var explicitName string
+		if spec.Name != nil {
+			explicitName = spec.Name.Name
+		}
+
+		astutil.DeleteNamedImport(fset, file, explicitName, importPath)
+	}
+}
+
+// referencedPackages returns the names used as packages by the file, i.e.
+// the ones selected from without being declared by the file.
+func referencedPackages(file *ast.File) map[string]bool {
+	used := make(map[string]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if id, ok := sel.X.(*ast.Ident); ok && id.Obj == nil {
+			used[id.Name] = true
+		}
+
+		return true
+	})
+
+	return used
+}
+
+// importName returns the name an import is referenced by, assuming it is
+// named after the last element of its path when not explicitly named,
+// ignoring major version suffixes and "go-" prefixes, like the go tools do.
+func importName(spec *ast.ImportSpec) string {
+	if spec.Name != nil {
+		return spec.Name.Name
+	}
+
+	importPath, err := strconv.Unquote(spec.Path.Value)
+	if err != nil {
+		return ""
+	}
+
+	base := path.Base(importPath)
+	if strings.HasPrefix(base, "v") {
+		if _, err := strconv.Atoi(base[1:]); err == nil {
+			if dir := path.Dir(importPath); dir != "." {
+				base = path.Base(dir)
+			}
+		}
+	}
+
+	base = strings.TrimPrefix(base, "go-")
+	if i := strings.IndexFunc(base, func(r rune) bool {
+		return r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}); i >= 0 {
+		base = base[:i]
+	}
+
+	return base
+}
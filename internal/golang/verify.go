@@ -0,0 +1,199 @@
+package golang
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mikros-dev/mikros-cli/internal/process"
+)
+
+var (
+	// buildErrorRegex matches errors reported by the go command, like
+	// './main.go:12:5: undefined: x'.
+	buildErrorRegex = regexp.MustCompile(`(?m)^(?:vet: )?([^\s:]+\.go):(\d+):(\d+): (.+)$`)
+)
+
+// BuildError is a compile error of a Go source file.
+type BuildError struct {
+	Filename string
+	Line     int
+	Column   int
+	Message  string
+}
+
+type VerifyOptions struct {
+	// Replaces points modules, by their paths, to local directories.
+	Replaces map[string]string
+}
+
+// Verify checks if the Go module at the current working directory builds
+// and passes go vet, resolving its dependencies only from the local module
+// cache. Compile errors of the module files are returned as BuildError,
+// while errors that prevent the verification from running, like
+// dependencies that can't be loaded, are returned as error. The module
+// go.mod and go.sum files are left as they were.
+func Verify(options *VerifyOptions) (_ []*BuildError, retErr error) {
+	offlineEnv, err := offlineEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	restore, err := saveModuleFiles()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := restore(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+
+	modules := make([]string, 0, len(options.Replaces))
+	for module := range options.Replaces {
+		modules = append(modules, module)
+	}
+	slices.Sort(modules)
+
+	for _, module := range modules {
+		replace := fmt.Sprintf("%s=%s", module, options.Replaces[module])
+		if out, err := process.ExecEnv(offlineEnv, "go", "mod", "edit", "-replace", replace); err != nil {
+			return nil, fmt.Errorf("go mod edit: %s", strings.TrimSpace(string(out)))
+		}
+	}
+
+	// Missing modules are not an error here, they're reported by the
+	// build pointing to the files importing them.
+	if out, err := process.ExecEnv(offlineEnv, "go", "mod", "tidy", "-e"); err != nil {
+		return nil, fmt.Errorf("go mod tidy: %s", strings.TrimSpace(string(out)))
+	}
+
+	for _, command := range [][]string{
+		{"go", "build", "./..."},
+		{"go", "vet", "./..."},
+	} {
+		out, err := process.ExecEnv(offlineEnv, command...)
+		if err == nil {
+			continue
+		}
+		if errs := parseBuildErrors(string(out)); len(errs) > 0 {
+			return errs, nil
+		}
+
+		// Errors outside the module, like dependencies missing from the
+		// local module cache, are not compile errors of the module.
+		return nil, fmt.Errorf("%s: dependencies could not be loaded: %s", strings.Join(command, " "), strings.TrimSpace(string(out)))
+	}
+
+	return nil, nil
+}
+
+// offlineEnv makes the go command only use modules from the local module
+// cache, which is served as a module proxy, adding the ones missing from
+// go.mod. Private modules are also served by it, and the local toolchain
+// is always used, so nothing is downloaded.
+func offlineEnv() ([]string, error) {
+	out, err := process.Exec("go", "env", "GOMODCACHE")
+	if err != nil {
+		return nil, fmt.Errorf("go env: %s", strings.TrimSpace(string(out)))
+	}
+
+	cache := filepath.Join(strings.TrimSpace(string(out)), "cache", "download")
+	return []string{
+		"GOFLAGS=-mod=mod",
+		"GOPROXY=file://" + filepath.ToSlash(cache),
+		"GOSUMDB=off",
+		"GOPRIVATE=",
+		"GONOPROXY=",
+		"GONOSUMDB=",
+		"GOTOOLCHAIN=local",
+		"GOWORK=off",
+	}, nil
+}
+
+// saveModuleFiles keeps the current content of go.mod and go.sum, which
+// are changed while verifying, returning a function that restores them.
+func saveModuleFiles() (func() error, error) {
+	contents := make(map[string][]byte)
+	for _, filename := range []string{"go.mod", "go.sum"} {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		contents[filename] = data
+	}
+
+	return func() error {
+		for _, filename := range []string{"go.mod", "go.sum"} {
+			data, ok := contents[filename]
+			if !ok {
+				if err := os.Remove(filename); err != nil && !errors.Is(err, fs.ErrNotExist) {
+					return err
+				}
+				continue
+			}
+
+			if err := os.WriteFile(filename, data, 0644); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, nil
+}
+
+// parseBuildErrors returns the errors of files inside the module, at the
+// current working directory. Errors of files from other modules, like the
+// ones of the module cache, are ignored.
+func parseBuildErrors(out string) []*BuildError {
+	var errs []*BuildError
+	for _, m := range buildErrorRegex.FindAllStringSubmatch(out, -1) {
+		filename, ok := moduleFilename(m[1])
+		if !ok {
+			continue
+		}
+
+		line, _ := strconv.Atoi(m[2])
+		column, _ := strconv.Atoi(m[3])
+
+		errs = append(errs, &BuildError{
+			Filename: filename,
+			Line:     line,
+			Column:   column,
+			Message:  m[4],
+		})
+	}
+
+	return errs
+}
+
+// moduleFilename returns filename relative to the module, at the current
+// working directory, if it is one of its files.
+func moduleFilename(filename string) (string, bool) {
+	if filepath.IsAbs(filename) {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return "", false
+		}
+
+		rel, err := filepath.Rel(cwd, filename)
+		if err != nil {
+			return "", false
+		}
+		filename = rel
+	}
+
+	filename = filepath.Clean(filename)
+	return filename, filepath.IsLocal(filename)
+}
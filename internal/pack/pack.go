@@ -0,0 +1,175 @@
+package pack
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/mikros-dev/mikros/components/definition"
+
+	"github.com/mikros-dev/mikros-cli/internal/path"
+)
+
+const (
+	// ManifestFilename is the name of the file, at the root of a pack,
+	// describing it.
+	ManifestFilename = "pack.toml"
+)
+
+// Pack is a versioned set of templates, installed from a directory or a
+// git repository, providing new kinds of projects to be created.
+type Pack struct {
+	Name        string  `toml:"name"`
+	Version     string  `toml:"version"`
+	Description string  `toml:"description"`
+	Kinds       []*Kind `toml:"kinds"`
+
+	path string
+}
+
+// Kind is a kind of project that a pack creates.
+type Kind struct {
+	Name        string          `toml:"name"`
+	Description string          `toml:"description"`
+	Context     []*ContextField `toml:"context"`
+	Files       []*File         `toml:"files"`
+}
+
+// ContextField is a field that must be filled by the user before the
+// project templates are executed. It is available to templates by its name,
+// like {{.ServiceName}}.
+type ContextField struct {
+	Name    string `toml:"name"`
+	Message string `toml:"message"`
+	Default string `toml:"default"`
+}
+
+// File is a template file of a kind of project.
+type File struct {
+	// Template is the template file path, relative to the pack root.
+	Template string `toml:"template"`
+
+	// Output is the path of the generated file, relative to the project
+	// directory. It can use the context fields, like "cmd/{{.Name}}/main.go".
+	Output string `toml:"output"`
+
+	// When is an optional condition, evaluated against the context fields,
+	// like '{{eq .Database "postgres"}}', deciding if the file is generated.
+	When string `toml:"when"`
+}
+
+// Load loads the pack located at a directory.
+func Load(dir string) (*Pack, error) {
+	var p Pack
+	if _, err := toml.DecodeFile(filepath.Join(dir, ManifestFilename), &p); err != nil {
+		return nil, err
+	}
+
+	p.path = dir
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", dir, err)
+	}
+
+	return &p, nil
+}
+
+// Validate checks if the pack manifest is consistent.
+func (p *Pack) Validate() error {
+	var errs []error
+
+	if p.Name == "" {
+		errs = append(errs, errors.New("pack has no name"))
+	}
+	if p.Name != "" && !isValidName(p.Name) {
+		errs = append(errs, fmt.Errorf("pack name '%s' is invalid", p.Name))
+	}
+	if !definition.ValidateVersion(p.Version) {
+		errs = append(errs, fmt.Errorf("pack version '%s' is invalid, it must be like v1.0.0", p.Version))
+	}
+	if len(p.Kinds) == 0 {
+		errs = append(errs, errors.New("pack has no project kinds"))
+	}
+
+	var kinds []string
+	for _, k := range p.Kinds {
+		if slices.Contains(kinds, k.Name) {
+			errs = append(errs, fmt.Errorf("kind '%s' is declared more than once", k.Name))
+			continue
+		}
+		kinds = append(kinds, k.Name)
+
+		errs = append(errs, p.validateKind(k)...)
+	}
+
+	return errors.Join(errs...)
+}
+
+func (p *Pack) validateKind(k *Kind) []error {
+	var errs []error
+
+	if k.Name == "" {
+		return append(errs, errors.New("kind has no name"))
+	}
+	if len(k.Files) == 0 {
+		errs = append(errs, fmt.Errorf("kind '%s' has no files", k.Name))
+	}
+
+	var fields []string
+	for _, c := range k.Context {
+		if c.Name == "" {
+			errs = append(errs, fmt.Errorf("kind '%s' has a context field without name", k.Name))
+			continue
+		}
+		if slices.Contains(fields, c.Name) {
+			errs = append(errs, fmt.Errorf("kind '%s' context field '%s' is declared more than once", k.Name, c.Name))
+		}
+		fields = append(fields, c.Name)
+	}
+
+	for _, f := range k.Files {
+		if !isRelativePath(f.Template) {
+			errs = append(errs, fmt.Errorf("kind '%s' template '%s' must be inside the pack", k.Name, f.Template))
+			continue
+		}
+		if !path.FindPath(filepath.Join(p.path, f.Template)) {
+			errs = append(errs, fmt.Errorf("kind '%s' template '%s' does not exist", k.Name, f.Template))
+		}
+		if f.Output == "" {
+			errs = append(errs, fmt.Errorf("kind '%s' template '%s' has no output", k.Name, f.Template))
+		}
+	}
+
+	return errs
+}
+
+// isValidName checks if a pack name can be used as the name of its
+// directory, without pointing outside the packs directory.
+func isValidName(name string) bool {
+	return name != "" && name != "." && !strings.ContainsAny(name, `/\`) && !strings.Contains(name, "..")
+}
+
+// isRelativePath checks if p is a relative path that does not leave its
+// base directory.
+func isRelativePath(p string) bool {
+	return p != "" && filepath.IsLocal(filepath.FromSlash(p))
+}
+
+// Kind returns a kind of project of the pack.
+func (p *Pack) Kind(name string) (*Kind, bool) {
+	idx := slices.IndexFunc(p.Kinds, func(k *Kind) bool {
+		return k.Name == name
+	})
+	if idx == -1 {
+		return nil, false
+	}
+
+	return p.Kinds[idx], true
+}
+
+// Path returns the directory where the pack is located.
+func (p *Pack) Path() string {
+	return p.path
+}
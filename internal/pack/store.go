@@ -0,0 +1,198 @@
+package pack
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mikros-dev/mikros-cli/internal/path"
+	"github.com/mikros-dev/mikros-cli/internal/process"
+	"github.com/mikros-dev/mikros-cli/internal/settings"
+)
+
+// InstallOptions gives options to install a pack.
+type InstallOptions struct {
+	// Ref is an optional branch or tag to install when the source is a git
+	// repository.
+	Ref string
+}
+
+// List returns all packs installed. Packs that can't be loaded, like the
+// ones partially installed, are skipped with a warning, so they don't
+// prevent using the other ones.
+func List(cfg *settings.Settings) ([]*Pack, error) {
+	entries, err := os.ReadDir(packsPath(cfg))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var packs []*Pack
+	for _, entry := range entries {
+		dir := filepath.Join(packsPath(cfg), entry.Name())
+		if !entry.IsDir() || !path.FindPath(filepath.Join(dir, ManifestFilename)) {
+			continue
+		}
+
+		p, err := Load(dir)
+		if err != nil {
+			fmt.Printf("⚠️  Skipping invalid template pack '%s': %v\n", entry.Name(), err)
+			continue
+		}
+
+		packs = append(packs, p)
+	}
+
+	return packs, nil
+}
+
+// Find returns an installed pack by its name.
+func Find(cfg *settings.Settings, name string) (*Pack, error) {
+	if !isValidName(name) {
+		return nil, fmt.Errorf("pack name '%s' is invalid", name)
+	}
+
+	dir := filepath.Join(packsPath(cfg), name)
+	if !path.FindPath(filepath.Join(dir, ManifestFilename)) {
+		return nil, fmt.Errorf("pack '%s' is not installed", name)
+	}
+
+	return Load(dir)
+}
+
+// Install installs a pack from a local directory or a git repository. A
+// previously installed pack with the same name is replaced.
+func Install(cfg *settings.Settings, source string, options *InstallOptions) (*Pack, error) {
+	if _, err := path.CreatePath(packsPath(cfg)); err != nil {
+		return nil, err
+	}
+
+	tmpDir, err := os.MkdirTemp(packsPath(cfg), ".install-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	src := filepath.Join(tmpDir, "pack")
+	if path.FindPath(source) {
+		if options.Ref != "" {
+			return nil, errors.New("a ref can only be used when installing from a git repository")
+		}
+		if err := copyDir(source, src); err != nil {
+			return nil, err
+		}
+	} else if isGitSource(source) {
+		if err := clone(source, src, options.Ref); err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, fmt.Errorf("pack source '%s' is neither a directory nor a git repository", source)
+	}
+
+	p, err := Load(src)
+	if err != nil {
+		return nil, err
+	}
+
+	dst := filepath.Join(packsPath(cfg), p.Name)
+	if err := os.RemoveAll(dst); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(src, dst); err != nil {
+		return nil, err
+	}
+
+	p.path = dst
+	return p, nil
+}
+
+// Remove removes an installed pack.
+func Remove(cfg *settings.Settings, name string) error {
+	p, err := Find(cfg, name)
+	if err != nil {
+		return err
+	}
+
+	return os.RemoveAll(p.Path())
+}
+
+func packsPath(cfg *settings.Settings) string {
+	return os.ExpandEnv(cfg.Paths.Packs)
+}
+
+func isGitSource(source string) bool {
+	return strings.Contains(source, "://") || strings.HasPrefix(source, "git@") || strings.HasSuffix(source, ".git")
+}
+
+func clone(source, dst, ref string) error {
+	args := []string{"git", "clone", "--depth", "1"}
+	if ref != "" {
+		args = append(args, "--branch", ref)
+	}
+
+	if out, err := process.Exec(append(args, source, dst)...); err != nil {
+		return fmt.Errorf("could not clone '%s': %s", source, strings.TrimSpace(string(out)))
+	}
+
+	// The repository history is not part of the pack.
+	return os.RemoveAll(filepath.Join(dst, ".git"))
+}
+
+// copyDir copies the src directory tree into dst, ignoring VCS data.
+func copyDir(src, dst string) error {
+	return filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+
+		return copyFile(p, target)
+	})
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = out.Close()
+	}()
+
+	_, err = io.Copy(out, in)
+	return err
+}
@@ -1,6 +1,7 @@
 package path
 
 import (
+	"fmt"
 	"os"
 	"path"
 	"path/filepath"
@@ -46,6 +47,18 @@ func FindPath(p string) bool {
 	return !os.IsNotExist(err)
 }
 
+// JoinLocal joins the slash separated path p to root, making sure that the
+// result stays inside root. Absolute paths or paths leaving root, like
+// "../file", are refused.
+func JoinLocal(root, p string) (string, error) {
+	local := filepath.FromSlash(p)
+	if p == "" || !filepath.IsLocal(local) {
+		return "", fmt.Errorf("path '%s' must be a relative path without '..' elements", p)
+	}
+
+	return filepath.Join(root, local), nil
+}
+
 // ChangeDir executes a chdir into path returning the old current working
 // directory.
 func ChangeDir(path string) (string, error) {
@@ -35,6 +35,12 @@ func (f *Feature) exec(args ...string) (string, error) {
 			return "", err
 		}
 
+		if len(d.FieldErrors) > 0 {
+			return "", &survey.ValidationError{
+				Fields: d.FieldErrors,
+			}
+		}
+
 		return "", errors.New(d.Error)
 	}
 
@@ -104,3 +110,9 @@ func (f *Feature) ValidateAnswers(answers map[string]interface{}) (map[string]in
 
 	return d.Answers, nil
 }
+
+// SurveyName returns the name that the feature survey receives when it is
+// executed.
+func (f *Feature) SurveyName() (string, error) {
+	return f.GetUIName()
+}
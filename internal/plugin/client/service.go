@@ -6,6 +6,7 @@ import (
 	"errors"
 	"os/exec"
 	"path/filepath"
+	"strings"
 
 	"github.com/mikros-dev/mikros-cli/internal/plugin/data"
 	"github.com/mikros-dev/mikros-cli/pkg/survey"
@@ -25,17 +26,29 @@ func NewService(path, name string) *Service {
 func (s *Service) exec(args ...string) (string, error) {
 	cmd := exec.Command(s.name, args...)
 
-	var out bytes.Buffer
+	var out, stderr bytes.Buffer
 	cmd.Stdout = &out
+	cmd.Stderr = &stderr
 
 	if err := cmd.Run(); err != nil {
 		// Error here must be decoded from stdout
-		d, err := data.DecodePluginData(out.String())
-		if err != nil {
-			// Nothing to do here, not our error
+		d, decodeErr := data.DecodePluginData(out.String())
+		if decodeErr != nil {
+			// Not our error, like unsupported options of plugins built
+			// with older versions.
+			if msg, _, _ := strings.Cut(strings.TrimSpace(stderr.String()), "\n"); msg != "" {
+				return "", errors.New(msg)
+			}
+
 			return "", err
 		}
 
+		if len(d.FieldErrors) > 0 {
+			return "", &survey.ValidationError{
+				Fields: d.FieldErrors,
+			}
+		}
+
 		return "", errors.New(d.Error)
 	}
 
@@ -110,3 +123,35 @@ func (s *Service) GetTemplates(answers map[string]interface{}) (*template.Templa
 
 	return d.Template, nil
 }
+
+// GetManifests returns the templates of the Kubernetes resources that the
+// plugin adds to the manifests of a service, receiving the service kind
+// settings. Plugins built before manifests were supported fail here.
+func (s *Service) GetManifests(settings map[string]interface{}) ([]*template.File, error) {
+	if settings == nil {
+		settings = make(map[string]interface{})
+	}
+
+	b, err := json.Marshal(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := s.exec("-m", "-i", string(b))
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := data.DecodePluginData(out)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.Manifests, nil
+}
+
+// SurveyName returns the name that the service survey receives when it is
+// executed.
+func (s *Service) SurveyName() (string, error) {
+	return s.GetKind()
+}
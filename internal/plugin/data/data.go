@@ -17,6 +17,13 @@ type PluginData struct {
 	Answers  map[string]interface{} `json:"answers,omitempty"`
 	Template *template.Template     `json:"template,omitempty"`
 	Error    string                 `json:"error,omitempty"`
+
+	// Manifests holds the Kubernetes resources that a plugin adds to the
+	// manifests of its services.
+	Manifests []*template.File `json:"manifests,omitempty"`
+
+	// FieldErrors holds the invalid answers when a plugin refuses them.
+	FieldErrors []*survey.FieldError `json:"field_errors,omitempty"`
 }
 
 func (p *PluginData) Output() error {
@@ -1,6 +1,8 @@
 package plugin
 
 import (
+	"errors"
+
 	"github.com/mikros-dev/mikros-cli/internal/plugin/data"
 	"github.com/mikros-dev/mikros-cli/pkg/survey"
 	"github.com/mikros-dev/mikros-cli/pkg/template"
@@ -36,12 +38,21 @@ func (e *Encoder) SetTemplate(template *template.Template) {
 	e.Template = template
 }
 
+func (e *Encoder) SetManifests(manifests []*template.File) {
+	e.Manifests = manifests
+}
+
 func (e *Encoder) SetKind(kind string) {
 	e.Kind = kind
 }
 
 func (e *Encoder) SetError(err error) {
 	e.Error = err.Error()
+
+	var vErr *survey.ValidationError
+	if errors.As(err, &vErr) {
+		e.FieldErrors = vErr.Fields
+	}
 }
 
 func (e *Encoder) Output() error {
@@ -1,12 +1,14 @@
 package plugin
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/mikros-dev/mikros-cli/internal/path"
 	"github.com/mikros-dev/mikros-cli/internal/plugin/client"
 	"github.com/mikros-dev/mikros-cli/internal/settings"
+	"github.com/mikros-dev/mikros-cli/pkg/survey"
 )
 
 func GetNewServiceKinds(cfg *settings.Settings) ([]string, error) {
@@ -138,3 +140,85 @@ func GetFeaturePlugin(cfg *settings.Settings, name string) (*client.Feature, err
 
 	return nil, nil
 }
+
+// SurveyPlugin is a plugin, of any kind, that provides a survey.
+type SurveyPlugin interface {
+	GetSurvey() (*survey.Survey, error)
+	SurveyName() (string, error)
+}
+
+// FindSurveyPlugin looks for a plugin using the path of its executable or,
+// inside the configured plugins paths, using a service kind or a feature
+// name (or UI name).
+func FindSurveyPlugin(cfg *settings.Settings, name string) (SurveyPlugin, error) {
+	if path.IsExecutable(name) {
+		dir, file := filepath.Split(name)
+
+		// Only service plugins know their kind.
+		svc := client.NewService(dir, file)
+		if kind, err := svc.GetKind(); err == nil && kind != "" {
+			return svc, nil
+		}
+
+		return client.NewFeature(dir, file), nil
+	}
+
+	svc, err := GetServicePlugin(cfg, name)
+	if err != nil {
+		return nil, err
+	}
+	if svc != nil {
+		return svc, nil
+	}
+
+	feature, err := GetFeaturePlugin(cfg, name)
+	if err != nil {
+		return nil, err
+	}
+	if feature != nil {
+		return feature, nil
+	}
+
+	feature, err = getFeaturePluginByName(cfg, name)
+	if err != nil {
+		return nil, err
+	}
+	if feature != nil {
+		return feature, nil
+	}
+
+	return nil, fmt.Errorf("could not find plugin '%s'", name)
+}
+
+func getFeaturePluginByName(cfg *settings.Settings, name string) (*client.Feature, error) {
+	var (
+		basePath = cfg.Paths.Plugins.Features
+	)
+
+	if !path.FindPath(basePath) {
+		return nil, nil
+	}
+
+	files, err := os.ReadDir(basePath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		if !path.IsExecutable(filepath.Join(basePath, file.Name())) {
+			continue
+		}
+
+		p := client.NewFeature(basePath, file.Name())
+
+		featureName, err := p.GetName()
+		if err != nil {
+			return nil, err
+		}
+		if featureName == name {
+			return p, nil
+		}
+	}
+
+	return nil, nil
+}
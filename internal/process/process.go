@@ -2,6 +2,7 @@ package process
 
 import (
 	"errors"
+	"os"
 	"os/exec"
 )
 
@@ -14,3 +15,15 @@ func Exec(args ...string) ([]byte, error) {
 	cmd := exec.Command(args[0], args[1:]...) //nolint
 	return cmd.CombinedOutput()
 }
+
+// ExecEnv executes a known command locally, adding env to the current
+// environment variables.
+func ExecEnv(env []string, args ...string) ([]byte, error) {
+	if len(args) == 0 {
+		return nil, errors.New("can't execute a nil command")
+	}
+
+	cmd := exec.Command(args[0], args[1:]...) //nolint
+	cmd.Env = append(os.Environ(), env...)
+	return cmd.CombinedOutput()
+}
@@ -0,0 +1,126 @@
+package session
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+const (
+	currentVersion = 1
+)
+
+// Session holds every answer given while creating a new project, allowing
+// the same execution to be replayed later without any prompt.
+//
+// All methods can be called with a nil Session, which neither records nor
+// replays answers.
+type Session struct {
+	Version int                    `json:"version"`
+	Project string                 `json:"project"`
+	Answers map[string]interface{} `json:"answers"`
+
+	replay bool
+}
+
+// New creates an empty Session to record answers.
+func New() *Session {
+	return &Session{
+		Version: currentVersion,
+		Answers: make(map[string]interface{}),
+	}
+}
+
+// Load loads a previously recorded Session to be replayed.
+func Load(filename string) (*Session, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	if s.Version != currentVersion {
+		return nil, fmt.Errorf("%s: unsupported session version %d", filename, s.Version)
+	}
+	if s.Project == "" {
+		return nil, fmt.Errorf("%s: session has no project", filename)
+	}
+	if s.Answers == nil {
+		s.Answers = make(map[string]interface{})
+	}
+
+	s.replay = true
+	return &s, nil
+}
+
+// NewReplay creates a Session to replay answers that were not loaded from
+// a session file.
+func NewReplay(project string, answers map[string]interface{}) *Session {
+	if answers == nil {
+		answers = make(map[string]interface{})
+	}
+
+	return &Session{
+		Version: currentVersion,
+		Project: project,
+		Answers: answers,
+		replay:  true,
+	}
+}
+
+// IsReplay returns if answers must be taken from the session instead of
+// asked to the user.
+func (s *Session) IsReplay() bool {
+	return s != nil && s.replay
+}
+
+// SetProject records the kind of project being created.
+func (s *Session) SetProject(project string) {
+	if s != nil {
+		s.Project = project
+	}
+}
+
+// Set records the answers of a survey.
+func (s *Session) Set(key string, answers interface{}) {
+	if s != nil {
+		s.Answers[key] = answers
+	}
+}
+
+// Decode loads the recorded answers of a survey into out.
+func (s *Session) Decode(key string, out interface{}) error {
+	if s == nil {
+		return errors.New("no session available")
+	}
+
+	answers, ok := s.Answers[key]
+	if !ok {
+		return fmt.Errorf("session has no answers for '%s'", key)
+	}
+
+	b, err := json.Marshal(answers)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, out)
+}
+
+// Save writes the session into filename.
+func (s *Session) Save(filename string) error {
+	if s == nil {
+		return nil
+	}
+
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filename, append(b, '\n'), 0644)
+}
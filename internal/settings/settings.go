@@ -27,6 +27,14 @@ type Settings struct {
 
 type Path struct {
 	Plugins Plugins `toml:"plugins"`
+
+	// Templates is an optional directory with files overriding the
+	// built-in templates. It follows the same layout of the built-in
+	// ones, like 'service/main.tmpl' or 'protobuf_repository/root/Makefile.tmpl'.
+	Templates string `toml:"templates"`
+
+	// Packs is where template packs are installed.
+	Packs string `toml:"packs" default:"$HOME/.mikros/packs"`
 }
 
 type Plugins struct {
@@ -41,6 +49,10 @@ type Profile struct {
 type Project struct {
 	ProtobufMonorepo ProtobufMonorepo `toml:"protobuf_monorepo"`
 	Templates        Templates        `toml:"templates"`
+
+	// Replaces points Go modules, by their paths, to local directories
+	// when verifying generated services, for modules not published yet.
+	Replaces map[string]string `toml:"replaces"`
 }
 
 type ProtobufMonorepo struct {
@@ -50,6 +62,8 @@ type ProtobufMonorepo struct {
 }
 
 type Templates struct {
+	// Path overrides, for the profile, the global templates directory.
+	Path     string            `toml:"path"`
 	Protobuf ProtobufTemplates `toml:"protobuf"`
 }
 
@@ -85,6 +99,7 @@ func NewDefault() (*Settings, error) {
 
 	cfg.Paths.Plugins.Services = os.ExpandEnv(cfg.Paths.Plugins.Services)
 	cfg.Paths.Plugins.Features = os.ExpandEnv(cfg.Paths.Plugins.Features)
+	cfg.Paths.Packs = os.ExpandEnv(cfg.Paths.Packs)
 
 	return cfg, nil
 }
@@ -119,6 +134,45 @@ func (s *Settings) Write() error {
 	return nil
 }
 
+// TemplatesPath returns the directory with the user templates of a set of
+// built-in templates, like "service" or "protobuf_repository/root", for
+// the profile. It returns an empty string when no templates directory is
+// configured.
+func (s *Settings) TemplatesPath(profile, set string) string {
+	basePath := s.Paths.Templates
+	if p := s.getProfile(profile); p.Project.Templates.Path != "" {
+		basePath = p.Project.Templates.Path
+	}
+	if basePath == "" {
+		return ""
+	}
+
+	return filepath.Join(os.ExpandEnv(basePath), filepath.FromSlash(set))
+}
+
+// ModuleReplaces returns the Go modules replaced by local directories when
+// verifying generated services of the profile. Replaces of the profile
+// take precedence over the global ones.
+func (s *Settings) ModuleReplaces(profile string) map[string]string {
+	replaces := make(map[string]string)
+	for module, dir := range s.App.Project.Replaces {
+		replaces[module] = os.ExpandEnv(dir)
+	}
+	for module, dir := range s.getProfile(profile).Project.Replaces {
+		replaces[module] = os.ExpandEnv(dir)
+	}
+
+	return replaces
+}
+
+func (s *Settings) getProfile(name string) *Profile {
+	if p, ok := s.Profile[name]; ok && name != "default" {
+		return &p
+	}
+
+	return &s.App
+}
+
 func (s *Settings) GetTheme() *huh.Theme {
 	switch strings.ToLower(s.UI.Theme) {
 	case "charm":
@@ -7,12 +7,7 @@ import (
 )
 
 func ParseBlock(block string, api map[string]interface{}, data interface{}) (string, error) {
-	helperApi := defaultApi
-	for k, v := range api {
-		helperApi[k] = v
-	}
-
-	tpl, err := template.New("custom").Funcs(helperApi).Parse(block)
+	tpl, err := template.New("custom").Funcs(newFuncMap(api)).Parse(block)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,230 @@
+package template
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/scanner"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// templateErrorRegex matches errors from text/template, like
+	// 'template: main:12:5: executing "main" at <.Name>: ...'.
+	templateErrorRegex = regexp.MustCompile(`(?s)^template: (.+?):(\d+)(?::(\d+))?: (.*)$`)
+
+	// actionRegex matches template actions, like "{{.Name}}".
+	actionRegex = regexp.MustCompile(`\{\{.*?\}\}`)
+)
+
+// Error is an error of a template pointing where it happened.
+type Error struct {
+	// Origin tells where the template came from, like the built-in
+	// templates, a templates directory or a plugin.
+	Origin string
+
+	// Template is the name of the template being executed.
+	Template string
+
+	// Partial is the name of the partial where the error happened, when
+	// it's not in the template itself.
+	Partial string
+
+	// Line and Column of the error. When Generated is true, they point to
+	// the generated file instead of the template.
+	Line      int
+	Column    int
+	Generated bool
+
+	// Snippet shows the lines around the error.
+	Snippet string
+
+	// Context is the template context, only available when debugging.
+	Context string
+
+	Err error
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "template '%s'", e.Template)
+	if e.Partial != "" {
+		fmt.Fprintf(&b, " (partial '%s')", e.Partial)
+	}
+	if e.Origin != "" {
+		fmt.Fprintf(&b, " from %s", e.Origin)
+	}
+	if e.Line > 0 {
+		fmt.Fprintf(&b, " line %d", e.Line)
+		if e.Column > 0 {
+			fmt.Fprintf(&b, ", column %d", e.Column)
+		}
+		if e.Generated {
+			b.WriteString(" of the generated file")
+		}
+	}
+	fmt.Fprintf(&b, ": %v", e.Err)
+
+	if e.Snippet != "" {
+		b.WriteString("\n" + e.Snippet)
+	}
+	if e.Context != "" {
+		b.WriteString("\ncontext:\n" + e.Context)
+	}
+
+	return b.String()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// newTemplateError wraps an error from text/template, locating it inside
+// the template or one of its partials.
+func newTemplateError(t *Info, err error) *Error {
+	tErr := &Error{
+		Origin:   t.origin,
+		Template: t.templateName(),
+		Err:      err,
+	}
+
+	m := templateErrorRegex.FindStringSubmatch(err.Error())
+	if m == nil {
+		return tErr
+	}
+
+	tErr.Err = errors.New(m[4])
+	tErr.Line, _ = strconv.Atoi(m[2])
+	tErr.Column, _ = strconv.Atoi(m[3])
+	if m[1] != tErr.Template {
+		tErr.Partial = m[1]
+		if origin, ok := t.partialOrigins[m[1]]; ok {
+			tErr.Origin = origin
+		}
+	}
+	if source, ok := t.sources[m[1]]; ok {
+		tErr.Snippet = snippet(source, tErr.Line)
+	}
+
+	return tErr
+}
+
+// newFormatError wraps an error formatting the Go code generated by a
+// template, locating it inside the template when the failing line can be
+// found there, or inside the generated file otherwise.
+func newFormatError(t *Info, src []byte, err error) *Error {
+	tErr := &Error{
+		Origin:   t.origin,
+		Template: t.templateName(),
+		Err:      fmt.Errorf("generated invalid Go code: %w", err),
+	}
+
+	var list scanner.ErrorList
+	if !errors.As(err, &list) || len(list) == 0 {
+		return tErr
+	}
+
+	var (
+		lines     = strings.Split(string(src), "\n")
+		generated string
+	)
+	if line := list[0].Pos.Line; line >= 1 && line <= len(lines) {
+		generated = strings.TrimSpace(lines[line-1])
+	}
+	tErr.Err = fmt.Errorf("generated invalid Go code: %s, at '%s'", list[0].Msg, generated)
+
+	if name, line, ok := templateLine(t, generated); ok {
+		if name != tErr.Template {
+			tErr.Partial = name
+			if origin, ok := t.partialOrigins[name]; ok {
+				tErr.Origin = origin
+			}
+		}
+		tErr.Line = line
+		tErr.Snippet = snippet(t.sources[name], line)
+		return tErr
+	}
+
+	tErr.Line = list[0].Pos.Line
+	tErr.Column = list[0].Pos.Column
+	tErr.Generated = true
+	tErr.Snippet = snippet(string(src), tErr.Line)
+
+	return tErr
+}
+
+// templateLine finds the line of the template, or of one of its partials,
+// that generated a line of its output. Template lines are compared with
+// their actions matching any text, and the line is only found when a
+// single template line matches it.
+func templateLine(t *Info, generated string) (string, int, bool) {
+	if generated == "" {
+		return "", 0, false
+	}
+
+	var (
+		found bool
+		name  string
+		line  int
+	)
+
+	for sourceName, source := range t.sources {
+		for i, l := range strings.Split(source, "\n") {
+			l = strings.TrimSpace(l)
+
+			// Lines with only actions match any output.
+			literals := actionRegex.Split(l, -1)
+			if strings.TrimSpace(strings.Join(literals, "")) == "" {
+				continue
+			}
+
+			for j := range literals {
+				literals[j] = regexp.QuoteMeta(literals[j])
+			}
+			re, err := regexp.Compile("^" + strings.Join(literals, ".*") + "$")
+			if err != nil || !re.MatchString(generated) {
+				continue
+			}
+			if found {
+				return "", 0, false
+			}
+
+			found, name, line = true, sourceName, i+1
+		}
+	}
+
+	return name, line, found
+}
+
+// snippet returns the lines around line, marking it.
+func snippet(source string, line int) string {
+	lines := strings.Split(source, "\n")
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+
+	var b strings.Builder
+	for i := max(line-2, 1); i <= min(line+2, len(lines)); i++ {
+		marker := " "
+		if i == line {
+			marker = ">"
+		}
+
+		fmt.Fprintf(&b, "%s %4d | %s\n", marker, i, lines[i-1])
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// dumpContext returns a readable representation of a template context.
+func dumpContext(ctx interface{}) string {
+	b, err := json.MarshalIndent(ctx, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("%#v", ctx)
+	}
+
+	return string(b)
+}
@@ -0,0 +1,362 @@
+package template
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"maps"
+	"path"
+	"reflect"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/BurntSushi/toml"
+	"github.com/iancoleman/strcase"
+)
+
+// defaultApi holds the functions available to every template, embedded,
+// from template packs, from plugins or evaluated with ParseBlock. Functions
+// receiving a string to operate on take it as their last argument so they
+// can be used in pipelines, like {{.Name | trimSuffix "Service" | toSnake}}.
+//
+// It must never be modified, templates receive their own copy from
+// newFuncMap.
+var defaultApi = template.FuncMap{
+	// Case conversion
+	"toCamel":      strcase.ToCamel,
+	"toSnake":      strcase.ToSnake,
+	"toUpperSnake": strcase.ToScreamingSnake,
+	"toKebab":      strcase.ToKebab,
+	"lower":        strings.ToLower,
+	"upper":        strings.ToUpper,
+	"title":        title,
+
+	// Words
+	"plural":   plural,
+	"singular": singular,
+
+	// Strings
+	"basename":   path.Base,
+	"join":       join,
+	"split":      split,
+	"contains":   contains,
+	"trimPrefix": trimPrefix,
+	"trimSuffix": trimSuffix,
+	"indent":     indent,
+	"nindent":    nindent,
+
+	// Values
+	"default": defaultValue,
+	"dict":    dict,
+	"list":    list,
+
+	// Encoding
+	"toJson": toJson,
+	"toToml": toToml,
+
+	// Go source
+	"toGoIdentifier": toGoIdentifier,
+}
+
+// newFuncMap creates the functions of a template, with the default ones and
+// the custom api, which can replace them.
+func newFuncMap(api map[string]interface{}) template.FuncMap {
+	funcs := make(template.FuncMap, len(defaultApi)+len(api)+1)
+	maps.Copy(funcs, defaultApi)
+	maps.Copy(funcs, api)
+
+	return funcs
+}
+
+// title upper cases the first letter of every word of s.
+func title(s string) string {
+	var (
+		b    strings.Builder
+		prev = ' '
+	)
+
+	for _, r := range s {
+		if unicode.IsSpace(prev) || prev == '-' || prev == '_' {
+			b.WriteRune(unicode.ToTitle(r))
+		} else {
+			b.WriteRune(r)
+		}
+		prev = r
+	}
+
+	return b.String()
+}
+
+var (
+	irregularPlurals = map[string]string{
+		"person": "people",
+		"child":  "children",
+		"man":    "men",
+		"woman":  "women",
+		"mouse":  "mice",
+		"goose":  "geese",
+		"tooth":  "teeth",
+		"foot":   "feet",
+		"leaf":   "leaves",
+		"life":   "lives",
+		"knife":  "knives",
+		"wife":   "wives",
+		"half":   "halves",
+		"datum":  "data",
+		"index":  "indices",
+	}
+
+	uncountables = []string{
+		"data", "metadata", "information", "equipment", "news", "series",
+		"species", "sheep", "fish", "settings",
+	}
+)
+
+// plural returns the English plural form of a word, like "entities" for
+// "entity". The case of its first letter is kept.
+func plural(word string) string {
+	lower := strings.ToLower(word)
+	if lower == "" || isUncountable(lower) {
+		return word
+	}
+	if p, ok := irregularPlurals[lower]; ok {
+		return keepCase(word, p)
+	}
+
+	switch {
+	case hasAnySuffix(lower, "s", "x", "z", "ch", "sh"):
+		return word + "es"
+	case strings.HasSuffix(lower, "y") && len(lower) > 1 && !isVowel(lower[len(lower)-2]):
+		return word[:len(word)-1] + "ies"
+	}
+
+	return word + "s"
+}
+
+// singular returns the English singular form of a word, like "entity" for
+// "entities". The case of its first letter is kept.
+func singular(word string) string {
+	lower := strings.ToLower(word)
+	if lower == "" || isUncountable(lower) {
+		return word
+	}
+	for s, p := range irregularPlurals {
+		if lower == p {
+			return keepCase(word, s)
+		}
+	}
+
+	switch {
+	case strings.HasSuffix(lower, "ies") && len(lower) > 3:
+		return word[:len(word)-3] + "y"
+	case hasAnySuffix(lower, "sses", "xes", "zes", "ches", "shes"):
+		return word[:len(word)-2]
+	case strings.HasSuffix(lower, "uses") && len(lower) > 4 && !isVowel(lower[len(lower)-5]):
+		// Words ending with "us", like "statuses" or "buses", while the
+		// ones ending with "use", like "houses", fall to the generic rule.
+		return word[:len(word)-2]
+	case strings.HasSuffix(lower, "ss") || strings.HasSuffix(lower, "us"):
+		return word
+	case strings.HasSuffix(lower, "s"):
+		return word[:len(word)-1]
+	}
+
+	return word
+}
+
+func isUncountable(word string) bool {
+	for _, u := range uncountables {
+		if word == u {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasAnySuffix(s string, suffixes ...string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(s, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isVowel(c byte) bool {
+	return strings.IndexByte("aeiou", c) != -1
+}
+
+// keepCase returns replacement with the first letter upper cased if
+// original starts with an upper case letter.
+func keepCase(original, replacement string) string {
+	if r := []rune(original); len(r) > 0 && unicode.IsUpper(r[0]) {
+		return strings.ToUpper(replacement[:1]) + replacement[1:]
+	}
+
+	return replacement
+}
+
+// join concatenates the elements of a list, like a []string or a list
+// created with the 'list' function, using sep between them.
+func join(sep string, values interface{}) string {
+	return strings.Join(toStrings(values), sep)
+}
+
+// split slices s into all substrings separated by sep.
+func split(sep, s string) []string {
+	return strings.Split(s, sep)
+}
+
+// contains checks if a string contains a substring or if a list contains an
+// element.
+func contains(needle, haystack interface{}) bool {
+	if s, ok := haystack.(string); ok {
+		return strings.Contains(s, fmt.Sprint(needle))
+	}
+
+	v := reflect.ValueOf(haystack)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return false
+	}
+	for i := 0; i < v.Len(); i++ {
+		if reflect.DeepEqual(v.Index(i).Interface(), needle) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func trimPrefix(prefix, s string) string {
+	return strings.TrimPrefix(s, prefix)
+}
+
+func trimSuffix(suffix, s string) string {
+	return strings.TrimSuffix(s, suffix)
+}
+
+// indent adds n spaces at the beginning of every line of s.
+func indent(n int, s string) string {
+	pad := strings.Repeat(" ", n)
+	return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+}
+
+// nindent works like indent but starts with a new line, which is useful to
+// indent blocks at the end of a line.
+func nindent(n int, s string) string {
+	return "\n" + indent(n, s)
+}
+
+// defaultValue returns value or, if it is empty, def.
+func defaultValue(def, value interface{}) interface{} {
+	if value == nil {
+		return def
+	}
+
+	v := reflect.ValueOf(value)
+	if v.IsZero() {
+		return def
+	}
+	if (v.Kind() == reflect.Slice || v.Kind() == reflect.Map) && v.Len() == 0 {
+		return def
+	}
+
+	return value
+}
+
+// dict creates a map from a list of key and value pairs, like
+// {{template "field" dict "Name" .Name "Type" "string"}}.
+func dict(pairs ...interface{}) (map[string]interface{}, error) {
+	if len(pairs)%2 != 0 {
+		return nil, fmt.Errorf("dict requires key and value pairs, got %d arguments", len(pairs))
+	}
+
+	m := make(map[string]interface{}, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		key, ok := pairs[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("dict keys must be strings, got %T", pairs[i])
+		}
+
+		m[key] = pairs[i+1]
+	}
+
+	return m, nil
+}
+
+// list creates a list from its arguments.
+func list(values ...interface{}) []interface{} {
+	return values
+}
+
+func toJson(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// toToml encodes v, which must be a map or a struct, as TOML.
+func toToml(v interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(v); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// toGoIdentifier converts s into a valid Go identifier by replacing invalid
+// characters with underscores, like "order_id" for "order-id". Identifiers
+// starting with a digit or matching a Go keyword receive an underscore.
+func toGoIdentifier(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			b.WriteRune(r)
+			continue
+		}
+
+		b.WriteRune('_')
+	}
+
+	id := b.String()
+	if id == "" {
+		return "_"
+	}
+	if unicode.IsDigit([]rune(id)[0]) {
+		id = "_" + id
+	}
+	if token.IsKeyword(id) {
+		id += "_"
+	}
+
+	return id
+}
+
+func toStrings(values interface{}) []string {
+	switch v := values.(type) {
+	case []string:
+		return v
+	case string:
+		return []string{v}
+	}
+
+	rv := reflect.ValueOf(values)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return []string{fmt.Sprint(values)}
+	}
+
+	s := make([]string, rv.Len())
+	for i := range s {
+		s[i] = fmt.Sprint(rv.Index(i).Interface())
+	}
+
+	return s
+}
@@ -4,31 +4,51 @@ import (
 	"bufio"
 	"bytes"
 	"embed"
+	"errors"
 	"fmt"
-	"path"
+	"io/fs"
+	"maps"
+	"os"
 	"path/filepath"
 	"slices"
+	"strings"
+	"sync"
 	"text/template"
 
-	"github.com/iancoleman/strcase"
+	"github.com/mikros-dev/mikros-cli/internal/golang"
 )
 
-var defaultApi = template.FuncMap{
-	"toCamel":      strcase.ToCamel,
-	"toSnake":      strcase.ToSnake,
-	"toUpperSnake": strcase.ToScreamingSnake,
-	"basename":     path.Base,
-	"toKebab":      strcase.ToKebab,
-}
-
+// Session holds templates ready to be executed. It is not modified after
+// being created, so it can be executed concurrently.
 type Session struct {
 	loadedTemplates []*Info
+	api             map[string]interface{}
+	debug           bool
 }
 
 type Info struct {
 	template *template.Template
 	name     File
 	context  interface{}
+	origin   string
+
+	// sources holds the content of the template and its partials, by their
+	// names, to point where errors happen.
+	sources map[string]string
+
+	// partialOrigins tells where partials came from, when it is known.
+	partialOrigins map[string]string
+
+	// raw holds the content of files that are not templates.
+	raw []byte
+}
+
+func (i *Info) templateName() string {
+	if i.template != nil {
+		return i.template.Name()
+	}
+
+	return i.name.Filename()
 }
 
 // File is representation of a template file to be processed when
@@ -44,28 +64,93 @@ type File struct {
 
 	// Extension is an optional field to set the file extension.
 	Extension string
+
+	// Mode is an optional permission of the generated file, like 0755 for
+	// scripts.
+	Mode fs.FileMode
+
+	// Raw marks files whose content must be written as is, without being
+	// executed as a template.
+	Raw bool
+
+	// When is an optional template expression, evaluated against the
+	// template context, like "{{.HasOnStart}}". The file is only generated
+	// when it results in a true value.
+	When string
+}
+
+// Filename returns the name of the file generated by the template.
+func (f File) Filename() string {
+	filename := f.Name
+	if f.Output != "" {
+		filename = f.Output
+	}
+	if f.Extension != "" {
+		filename += fmt.Sprintf(".%v", f.Extension)
+	}
+
+	return filename
 }
 
 type LoadOptions struct {
 	TemplateNames []File
 	Api           map[string]interface{}
+
+	// Directory is an optional path with template files that take
+	// precedence, by name, over the embedded ones.
+	Directory string
+
+	// Partials are templates, by their names, available to every template
+	// of the session with {{template "name" .}}. Embedded files named like
+	// "_name.tmpl" are also loaded as partials.
+	Partials map[string]string
+
+	// Origin tells, in errors, where templates loaded from data came from,
+	// like "plugin 'worker'".
+	Origin string
+
+	// Debug adds the template context to execution errors.
+	Debug bool
 }
 
 func NewSessionFromFiles(options *LoadOptions, files embed.FS) (*Session, error) {
-	dirFiles, err := files.ReadDir(".")
+	sources, err := templateSources(options.Directory, files)
+	if err != nil {
+		return nil, err
+	}
+
+	partials, err := readPartials(sources)
 	if err != nil {
 		return nil, err
 	}
+	for name, content := range options.Partials {
+		partials[name] = content
+	}
+
+	opts := *options
+	opts.Partials = partials
+	options = &opts
+
+	origins := make(map[string]string)
+	for _, source := range sources {
+		if isPartial(source.filename) {
+			origins[partialName(source.filename)] = source.origin
+		}
+	}
 
 	var templates []*Info
-	for _, file := range dirFiles {
-		data, err := files.ReadFile(file.Name())
+	for _, source := range sources {
+		if isPartial(source.filename) {
+			continue
+		}
+
+		data, err := fs.ReadFile(source.fsys, source.filename)
 		if err != nil {
 			return nil, err
 		}
 
 		var (
-			name = filenameWithoutExtension(file.Name())
+			name = filenameWithoutExtension(source.filename)
 		)
 
 		idx := slices.IndexFunc(options.TemplateNames, func(t File) bool {
@@ -76,22 +161,142 @@ func NewSessionFromFiles(options *LoadOptions, files embed.FS) (*Session, error)
 			continue
 		}
 
-		tpl, err := loadTemplate(name, data, options)
-		if err != nil {
-			return nil, err
+		info := &Info{
+			name:           options.TemplateNames[idx],
+			origin:         source.origin,
+			sources:        templateSourcesByName(name, data, options.Partials),
+			partialOrigins: origins,
+		}
+		if info.template, err = loadTemplate(name, data, options); err != nil {
+			return nil, newTemplateError(info, err)
 		}
 
-		templates = append(templates, &Info{
-			name:     options.TemplateNames[idx],
-			template: tpl,
-		})
+		templates = append(templates, info)
 	}
 
 	return &Session{
 		loadedTemplates: templates,
+		api:             maps.Clone(options.Api),
+		debug:           options.Debug,
 	}, nil
 }
 
+func templateSourcesByName(name string, data []byte, partials map[string]string) map[string]string {
+	sources := maps.Clone(partials)
+	if sources == nil {
+		sources = make(map[string]string)
+	}
+	sources[name] = string(data)
+
+	return sources
+}
+
+// Partials returns the partials found among the embedded template files,
+// considering the ones overridden inside directory.
+func Partials(directory string, files embed.FS) (map[string]string, error) {
+	sources, err := templateSources(directory, files)
+	if err != nil {
+		return nil, err
+	}
+
+	return readPartials(sources)
+}
+
+func readPartials(sources []*templateSource) (map[string]string, error) {
+	partials := make(map[string]string)
+	for _, source := range sources {
+		if !isPartial(source.filename) {
+			continue
+		}
+
+		data, err := fs.ReadFile(source.fsys, source.filename)
+		if err != nil {
+			return nil, err
+		}
+
+		partials[partialName(source.filename)] = string(data)
+	}
+
+	return partials, nil
+}
+
+// isPartial checks if a template file is a partial, i.e., a file named
+// like "_imports.tmpl".
+func isPartial(filename string) bool {
+	return strings.HasPrefix(filename, "_")
+}
+
+func partialName(filename string) string {
+	return strings.TrimPrefix(filenameWithoutExtension(filename), "_")
+}
+
+// templateSource is a template file and the filesystem it must be read from.
+type templateSource struct {
+	filename string
+	fsys     fs.FS
+	origin   string
+}
+
+// templateSources gives the embedded template files, replacing the ones
+// found inside directory with the same name. Templates that only exist
+// inside directory are also added.
+func templateSources(directory string, files embed.FS) ([]*templateSource, error) {
+	dirFiles, err := files.ReadDir(".")
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		sources = make([]*templateSource, 0, len(dirFiles))
+		indexes = make(map[string]int)
+	)
+
+	for _, file := range dirFiles {
+		indexes[filenameWithoutExtension(file.Name())] = len(sources)
+		sources = append(sources, &templateSource{
+			filename: file.Name(),
+			fsys:     files,
+			origin:   "built-in templates",
+		})
+	}
+
+	if directory == "" {
+		return sources, nil
+	}
+
+	userFiles, err := os.ReadDir(directory)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			// Nothing to override for these templates.
+			return sources, nil
+		}
+
+		return nil, err
+	}
+
+	userFS := os.DirFS(directory)
+	for _, file := range userFiles {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".tmpl" {
+			continue
+		}
+
+		source := &templateSource{
+			filename: file.Name(),
+			fsys:     userFS,
+			origin:   fmt.Sprintf("'%s'", directory),
+		}
+
+		if i, ok := indexes[filenameWithoutExtension(file.Name())]; ok {
+			sources[i] = source
+			continue
+		}
+
+		sources = append(sources, source)
+	}
+
+	return sources, nil
+}
+
 type Data struct {
 	FileName string
 	Content  []byte
@@ -118,20 +323,30 @@ func NewSessionFromData(options *LoadOptions, files []*Data) (*Session, error) {
 			continue
 		}
 
-		tpl, err := loadTemplate(name, file.Content, options)
-		if err != nil {
-			return nil, err
+		info := &Info{
+			name:    options.TemplateNames[idx],
+			context: file.Context,
+			origin:  options.Origin,
+		}
+		if options.TemplateNames[idx].Raw {
+			info.raw = file.Content
+			templates = append(templates, info)
+			continue
 		}
 
-		templates = append(templates, &Info{
-			name:     options.TemplateNames[idx],
-			template: tpl,
-			context:  file.Context,
-		})
+		var err error
+		info.sources = templateSourcesByName(name, file.Content, options.Partials)
+		if info.template, err = loadTemplate(name, file.Content, options); err != nil {
+			return nil, newTemplateError(info, err)
+		}
+
+		templates = append(templates, info)
 	}
 
 	return &Session{
 		loadedTemplates: templates,
+		api:             maps.Clone(options.Api),
+		debug:           options.Debug,
 	}, nil
 }
 
@@ -140,22 +355,30 @@ func filenameWithoutExtension(filename string) string {
 }
 
 func loadTemplate(name string, data []byte, options *LoadOptions) (*template.Template, error) {
-	var (
-		helperApi = defaultApi
-	)
-
+	helperApi := newFuncMap(options.Api)
 	helperApi["templateName"] = func() string {
 		return name
 	}
-	for call, function := range options.Api {
-		helperApi[call] = function
-	}
 
 	tpl, err := parse(name, data, helperApi)
 	if err != nil {
 		return nil, err
 	}
 
+	// Partials are associated with every template, so they can be called
+	// by their names. They're sorted to always report the same error.
+	names := make([]string, 0, len(options.Partials))
+	for partial := range options.Partials {
+		names = append(names, partial)
+	}
+	slices.Sort(names)
+
+	for _, partial := range names {
+		if _, err := tpl.New(partial).Parse(options.Partials[partial]); err != nil {
+			return nil, err
+		}
+	}
+
 	return tpl, nil
 }
 
@@ -168,46 +391,129 @@ func parse(key string, data []byte, helperApi template.FuncMap) (*template.Templ
 	return t, nil
 }
 
+// ExecuteTemplates executes all session templates, concurrently, returning
+// the generated files in the same order that templates were loaded.
 func (s *Session) ExecuteTemplates(ctx interface{}) ([]*GeneratedTemplate, error) {
-	var gen []*GeneratedTemplate
+	var (
+		wg        sync.WaitGroup
+		generated = make([]*GeneratedTemplate, len(s.loadedTemplates))
+		errs      = make([]error, len(s.loadedTemplates))
+	)
 
-	for _, t := range s.loadedTemplates {
-		var buf bytes.Buffer
-		w := bufio.NewWriter(&buf)
+	for i, t := range s.loadedTemplates {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			generated[i], errs[i] = s.executeTemplate(t, ctx)
+		}()
+	}
+	wg.Wait()
 
-		tplCtx := ctx
-		if ctx == nil {
-			tplCtx = t.context
+	var gen []*GeneratedTemplate
+	for i, g := range generated {
+		if errs[i] != nil {
+			return nil, errs[i]
 		}
-
-		if err := t.template.Execute(w, tplCtx); err != nil {
-			return nil, err
+		if g != nil {
+			gen = append(gen, g)
 		}
-
-		_ = w.Flush()
-		gen = append(gen, newGeneratedTemplate(&buf, t.name))
 	}
 
 	return gen, nil
 }
 
-type GeneratedTemplate struct {
-	data *bytes.Buffer
-	name string
+// executeTemplate executes a single template. It returns nil when the
+// template condition does not allow it to be generated.
+func (s *Session) executeTemplate(t *Info, ctx interface{}) (*GeneratedTemplate, error) {
+	tplCtx := ctx
+	if ctx == nil {
+		tplCtx = t.context
+	}
+
+	include, err := s.shouldGenerate(t, tplCtx)
+	if err != nil {
+		return nil, err
+	}
+	if !include {
+		return nil, nil
+	}
+
+	if t.name.Raw {
+		return newGeneratedTemplate(bytes.NewBuffer(t.raw), t), nil
+	}
+
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+
+	if err := t.template.Execute(w, tplCtx); err != nil {
+		tErr := newTemplateError(t, err)
+		if s.debug {
+			tErr.Context = dumpContext(tplCtx)
+		}
+
+		return nil, tErr
+	}
+
+	_ = w.Flush()
+	g := newGeneratedTemplate(&buf, t)
+
+	// Go sources are always formatted, so templates don't need to care
+	// about indentation or imports that end up not being used.
+	if filepath.Ext(g.name) == ".go" {
+		src, err := golang.Format(g.name, g.Content())
+		if err != nil {
+			return nil, newFormatError(t, g.Content(), err)
+		}
+		g.data = bytes.NewBuffer(src)
+	}
+
+	return g, nil
 }
 
-func newGeneratedTemplate(data *bytes.Buffer, name File) *GeneratedTemplate {
-	filename := name.Name
-	if name.Output != "" {
-		filename = name.Output
+// shouldGenerate evaluates the When condition of a template. Empty results,
+// "false", "0" and missing values are considered false.
+func (s *Session) shouldGenerate(t *Info, ctx interface{}) (bool, error) {
+	if t.name.When == "" {
+		return true, nil
+	}
+
+	out, err := ParseBlock(t.name.When, s.api, ctx)
+	if err != nil {
+		tErr := &Error{
+			Origin:   t.origin,
+			Template: t.templateName(),
+			Err:      fmt.Errorf("condition '%s': %w", t.name.When, err),
+		}
+		if s.debug {
+			tErr.Context = dumpContext(ctx)
+		}
+
+		return false, tErr
 	}
-	if name.Extension != "" {
-		filename += fmt.Sprintf(".%v", name.Extension)
+
+	switch strings.TrimSpace(out) {
+	case "", "false", "0", "<no value>":
+		return false, nil
 	}
 
+	return true, nil
+}
+
+type GeneratedTemplate struct {
+	data     *bytes.Buffer
+	name     string
+	mode     fs.FileMode
+	template string
+	origin   string
+}
+
+func newGeneratedTemplate(data *bytes.Buffer, t *Info) *GeneratedTemplate {
 	return &GeneratedTemplate{
-		data: data,
-		name: filename,
+		data:     data,
+		name:     t.name.Filename(),
+		mode:     t.name.Mode,
+		template: t.templateName(),
+		origin:   t.origin,
 	}
 }
 
@@ -218,3 +524,20 @@ func (g *GeneratedTemplate) Filename() string {
 func (g *GeneratedTemplate) Content() []byte {
 	return g.data.Bytes()
 }
+
+// Mode returns the permission that the generated file must have. A zero
+// value means the default file permission.
+func (g *GeneratedTemplate) Mode() fs.FileMode {
+	return g.mode
+}
+
+// Source describes the template that generated the file, like
+// "template 'main' from built-in templates".
+func (g *GeneratedTemplate) Source() string {
+	source := fmt.Sprintf("template '%s'", g.template)
+	if g.origin != "" {
+		source += " from " + g.origin
+	}
+
+	return source
+}
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/mikros-dev/mikros-cli/internal/template"
+)
+
+// SurveyContext holds information about the service being created that
+// question defaults can use inside their templates.
+type SurveyContext struct {
+	ServiceName string
+	ServiceType string
+	Product     string
+	Language    string
+
+	// Directory is the absolute path of the service directory, used to
+	// answer file and directory questions with relative paths.
+	Directory string
+}
+
+// defaultContext is the data available when a question default is
+// evaluated as a template.
+type defaultContext struct {
+	ServiceName string
+	ServiceType string
+	Product     string
+	Language    string
+	Answers     map[string]interface{}
+}
+
+func isTemplatedDefault(value string) bool {
+	return strings.Contains(value, "{{")
+}
+
+// evaluateDefault executes a question default as a template, giving it
+// access to the service context and to the answers already given.
+func evaluateDefault(value string, answers map[string]interface{}, options *FormOptions) (string, error) {
+	if !isTemplatedDefault(value) {
+		return value, nil
+	}
+
+	data := defaultContext{
+		Answers: answers,
+	}
+
+	if c := options.Context; c != nil {
+		data.ServiceName = c.ServiceName
+		data.ServiceType = c.ServiceType
+		data.Product = c.Product
+		data.Language = c.Language
+	}
+
+	return template.ParseBlock(value, nil, data)
+}
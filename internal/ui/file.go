@@ -0,0 +1,143 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+
+	"github.com/mikros-dev/mikros-cli/internal/path"
+	"github.com/mikros-dev/mikros-cli/pkg/survey"
+)
+
+// pathField creates the field of a PromptFile or PromptDirectory question.
+func (f *surveyForm) pathField(q *survey.Question, title string, previous interface{}) huh.Field {
+	value := ""
+	if v, ok := previous.(string); ok && v != "" {
+		value = f.fromServicePath(v)
+	}
+
+	f.values[q.Name] = &value
+	f.paths[q.Name] = true
+
+	if f.options.Accessible {
+		// The file picker accessible mode does not accept files without
+		// extension filters, so the path is typed instead.
+		return huh.NewInput().
+			Title(title).
+			Description(f.description(q)).
+			Placeholder(q.Placeholder).
+			Value(f.values[q.Name].(*string)).
+			Validate(pathValidator(q))
+	}
+
+	picker := huh.NewFilePicker().
+		Title(title).
+		Description(f.description(q)).
+		FileAllowed(q.Prompt == survey.PromptFile).
+		DirAllowed(q.Prompt == survey.PromptDirectory).
+		Value(f.values[q.Name].(*string)).
+		Validate(pathValidator(q))
+
+	if extensions := fileExtensions(q); len(extensions) > 0 {
+		picker = picker.AllowedTypes(extensions)
+	}
+
+	// The default, when it is an existing directory, is where the picker
+	// starts browsing.
+	if q.Default != "" && !isTemplatedDefault(q.Default) && path.FindPath(q.Default) {
+		picker = picker.CurrentDirectory(q.Default)
+	}
+
+	return picker
+}
+
+func fileExtensions(q *survey.Question) []string {
+	extensions := make([]string, len(q.Extensions))
+	for i, ext := range q.Extensions {
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		extensions[i] = ext
+	}
+
+	return extensions
+}
+
+func pathValidator(q *survey.Question) func(string) error {
+	return func(p string) error {
+		if p == "" {
+			if q.Required {
+				return errors.New("cannot be empty")
+			}
+
+			return nil
+		}
+
+		extensions := fileExtensions(q)
+		if q.Prompt == survey.PromptFile && len(extensions) > 0 && !slices.Contains(extensions, filepath.Ext(p)) {
+			return fmt.Errorf("must be a file with one of the extensions: %s", strings.Join(extensions, ", "))
+		}
+
+		if !q.MustExist {
+			return nil
+		}
+
+		info, err := os.Stat(p)
+		if err != nil {
+			return errors.New("path does not exist")
+		}
+		if q.Prompt == survey.PromptDirectory && !info.IsDir() {
+			return errors.New("must be a directory")
+		}
+		if q.Prompt == survey.PromptFile && info.IsDir() {
+			return errors.New("must be a file")
+		}
+
+		return nil
+	}
+}
+
+// toServicePath converts a path chosen from the current directory into a
+// path relative to the service directory.
+func (f *surveyForm) toServicePath(p string) string {
+	if f.options.Context == nil || f.options.Context.Directory == "" {
+		return p
+	}
+
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		return p
+	}
+
+	rel, err := filepath.Rel(f.options.Context.Directory, abs)
+	if err != nil {
+		return abs
+	}
+
+	return rel
+}
+
+// fromServicePath converts a path relative to the service directory back
+// into a path relative to the current directory.
+func (f *surveyForm) fromServicePath(p string) string {
+	if f.options.Context == nil || f.options.Context.Directory == "" || filepath.IsAbs(p) {
+		return p
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return p
+	}
+
+	rel, err := filepath.Rel(cwd, filepath.Join(f.options.Context.Directory, p))
+	if err != nil {
+		return p
+	}
+
+	return rel
+}
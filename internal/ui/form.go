@@ -14,6 +14,22 @@ import (
 type FormOptions struct {
 	Theme      *huh.Theme
 	Accessible bool
+
+	// Context is the service information available for questions that use
+	// templates as their default values.
+	Context *SurveyContext
+
+	// Answers holds answers from a previous execution of the same survey,
+	// used to fill its questions when it is presented again.
+	Answers map[string]interface{}
+
+	// Errors holds validation errors of previous answers to be displayed
+	// next to their questions.
+	Errors *survey.ValidationError
+
+	// previousAnswers holds answers from a parent survey when running
+	// follow-up surveys.
+	previousAnswers map[string]interface{}
 }
 
 func RunFormFromSurvey(name string, s *survey.Survey, options *FormOptions) (map[string]interface{}, error) {
@@ -25,11 +41,26 @@ func RunFormFromSurvey(name string, s *survey.Survey, options *FormOptions) (map
 }
 
 func runFormWithConfirmation(name string, s *survey.Survey, options *FormOptions) (map[string]interface{}, error) {
-	var results []map[string]interface{}
+	var (
+		results  []map[string]interface{}
+		previous []map[string]interface{}
+	)
+
+	if answers, ok := options.Answers[name].([]map[string]interface{}); ok {
+		previous = answers
+	}
+
+	// Each entry is filled with its previous answers, if any.
+	runEntry := func(answers map[string]interface{}) (map[string]interface{}, error) {
+		entryOptions := *options
+		entryOptions.Answers = answers
+
+		return runFormSurvey(name, s, &entryOptions)
+	}
 
 loop:
-	for {
-		if SurveyConfirmBefore(s) {
+	for !loopIsFull(s, len(results)) {
+		if SurveyConfirmBefore(s) && len(results) >= s.MinIterations {
 			confirm, err := yesNo(s.ConfirmQuestion.Message, s.ConfirmQuestion.Default)
 			if err != nil {
 				return nil, err
@@ -39,13 +70,18 @@ loop:
 			}
 		}
 
-		response, err := runFormSurvey(name, s, options)
+		var answers map[string]interface{}
+		if i := len(results); i < len(previous) {
+			answers = previous[i]
+		}
+
+		response, err := runEntry(answers)
 		if err != nil {
 			return nil, err
 		}
 		results = append(results, response)
 
-		if SurveyConfirmAfter(s) {
+		if SurveyConfirmAfter(s) && len(results) >= s.MinIterations && !loopIsFull(s, len(results)) {
 			confirm, err := yesNo(s.ConfirmQuestion.Message, s.ConfirmQuestion.Default)
 			if err != nil {
 				return nil, err
@@ -56,96 +92,162 @@ loop:
 		}
 	}
 
+	results, err := reviewLoopEntries(name, s, results, options, runEntry)
+	if err != nil {
+		return nil, err
+	}
+
 	return map[string]interface{}{
 		name: results,
 	}, nil
 }
 
-func runFormSurvey(name string, s *survey.Survey, options *FormOptions) (map[string]interface{}, error) {
-	var (
-		values   = make(map[string]interface{})
-		results  = make(map[string]interface{})
-		elements []huh.Field
-	)
+func loopIsFull(s *survey.Survey, entries int) bool {
+	return s.MaxIterations > 0 && entries >= s.MaxIterations
+}
+
+const (
+	reviewDone = -1
+	reviewAdd  = -2
+)
 
-	for _, q := range s.Questions {
+// reviewLoopEntries lets the user review the entries of a survey executed
+// inside a loop, allowing them to be edited, deleted or new ones added.
+func reviewLoopEntries(
+	name string,
+	s *survey.Survey,
+	results []map[string]interface{},
+	options *FormOptions,
+	runEntry func(map[string]interface{}) (map[string]interface{}, error),
+) ([]map[string]interface{}, error) {
+	for len(results) > 0 {
 		var (
-			title = fmt.Sprintf("[%s] %s", name, q.Message)
+			choice  = reviewDone
+			entries []huh.Option[int]
 		)
 
-		switch q.Prompt {
-		case survey.PromptInput:
-			defaultValue := q.Default
-			values[q.Name] = &defaultValue
+		for i, entry := range results {
+			entries = append(entries, huh.NewOption(LoopEntryLabel(name, s, i, entry), i))
+		}
+		if !loopIsFull(s, len(results)) {
+			entries = append(entries, huh.NewOption("Add a new entry", reviewAdd))
+		}
+		entries = append(entries, huh.NewOption("Done", reviewDone))
+
+		form := huh.NewForm(
+			huh.NewGroup(
+				huh.NewSelect[int]().
+					Title(fmt.Sprintf("[%s] Review the entries or choose Done to continue", name)).
+					Options(entries...).
+					Value(&choice),
+			),
+		).
+			WithTheme(options.Theme).
+			WithAccessible(options.Accessible)
+
+		if err := form.Run(); err != nil {
+			return nil, err
+		}
+
+		switch choice {
+		case reviewDone:
+			return results, nil
 
-			input := huh.NewInput().Title(title).Value(values[q.Name].(*string))
-			if q.Required {
-				input = input.Validate(IsEmpty("cannot be empty"))
+		case reviewAdd:
+			response, err := runEntry(nil)
+			if err != nil {
+				return nil, err
 			}
+			results = append(results, response)
 
-			elements = append(elements, input)
+		default:
+			action, err := reviewEntryAction(name, s, choice, results, options)
+			if err != nil {
+				return nil, err
+			}
 
-		case survey.PromptSelect:
-			options := make([]huh.Option[string], len(q.Options))
-			for i, option := range q.Options {
-				opt := huh.NewOption(option, option)
-				if option == q.Default {
-					opt = opt.Selected(true)
+			switch action {
+			case "edit":
+				response, err := runEntry(results[choice])
+				if err != nil {
+					return nil, err
 				}
-				options[i] = opt
+				results[choice] = response
+
+			case "delete":
+				results = slices.Delete(results, choice, choice+1)
 			}
+		}
+	}
 
-			values[q.Name] = new(string)
-			elements = append(elements, huh.NewSelect[string]().
-				Title(title).
-				Options(options...).
-				Value(values[q.Name].(*string)))
+	return results, nil
+}
 
-		case survey.PromptMultiSelect:
-			options := make([]huh.Option[string], len(q.Options))
-			for i, option := range q.Options {
-				options[i] = huh.NewOption(option, option)
-			}
+func reviewEntryAction(name string, s *survey.Survey, index int, results []map[string]interface{}, options *FormOptions) (string, error) {
+	actions := []huh.Option[string]{
+		huh.NewOption("Edit", "edit"),
+	}
+	if len(results) > s.MinIterations {
+		actions = append(actions, huh.NewOption("Delete", "delete"))
+	}
+	actions = append(actions, huh.NewOption("Back", "back"))
 
-			values[q.Name] = new([]string)
-			prompt := huh.NewMultiSelect[string]().Title(title).Options(options...).Value(values[q.Name].(*[]string))
-			if q.Required {
-				prompt = prompt.Validate(func(strings []string) error {
-					if len(strings) == 0 {
-						return errors.New("must choose at least one option")
-					}
+	var action string
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title(LoopEntryLabel(name, s, index, results[index])).
+				Options(actions...).
+				Value(&action),
+		),
+	).
+		WithTheme(options.Theme).
+		WithAccessible(options.Accessible)
 
-					return nil
-				})
-			}
+	if err := form.Run(); err != nil {
+		return "", err
+	}
 
-			elements = append(elements, prompt)
+	return action, nil
+}
 
-		case survey.PromptMultiline:
-			values[q.Name] = new(string)
-			text := huh.NewText().Title(title).Value(values[q.Name].(*string))
-			if q.Required {
-				text = text.Validate(IsEmpty("cannot be empty"))
-			}
+func runFormSurvey(name string, s *survey.Survey, options *FormOptions) (map[string]interface{}, error) {
+	var (
+		f      = newSurveyForm(name, options)
+		groups []*huh.Group
+	)
 
-			elements = append(elements, text)
+	for _, page := range SurveyPages(s) {
+		var (
+			elements []huh.Field
+		)
 
-		case survey.PromptConfirm:
-			defaultValue := false
-			if q.Default != "" {
-				if b, err := strconv.ParseBool(q.Default); err == nil {
-					defaultValue = b
-				}
+		for _, q := range page.Questions {
+			field, err := f.questionField(q)
+			if err != nil {
+				return nil, err
+			}
+			if field != nil {
+				elements = append(elements, field)
 			}
+		}
+
+		if len(elements) == 0 {
+			continue
+		}
 
-			values[q.Name] = &defaultValue
-			elements = append(elements, huh.NewConfirm().
-				Title(title).
-				Value(values[q.Name].(*bool)))
+		group := huh.NewGroup(elements...)
+		if page.Title != "" {
+			group = group.Title(page.Title)
+		}
+		if page.Description != "" {
+			group = group.Description(page.Description)
 		}
+
+		groups = append(groups, group)
 	}
 
-	form := huh.NewForm(huh.NewGroup(elements...)).
+	form := huh.NewForm(groups...).
 		WithTheme(options.Theme).
 		WithAccessible(options.Accessible)
 
@@ -153,6 +255,277 @@ func runFormSurvey(name string, s *survey.Survey, options *FormOptions) (map[str
 		return nil, err
 	}
 
+	results, err := f.answers()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if we have a follow-up survey to execute
+	if len(s.FollowUp) != 0 {
+		followUpResults, err := executeFollowUpSurvey(s.FollowUp, results, options)
+		if err != nil {
+			return nil, err
+		}
+		results[survey.FollowUpAnswersKey] = followUpResults
+	}
+
+	return results, nil
+}
+
+// surveyForm holds the values bound to the fields of a survey form while
+// it is built and executed.
+type surveyForm struct {
+	name      string
+	options   *FormOptions
+	values    map[string]interface{}
+	templated map[string]string
+	paths     map[string]bool
+}
+
+func newSurveyForm(name string, options *FormOptions) *surveyForm {
+	return &surveyForm{
+		name:      name,
+		options:   options,
+		values:    make(map[string]interface{}),
+		templated: make(map[string]string),
+		paths:     make(map[string]bool),
+	}
+}
+
+// questionField creates the form field that presents a question to the
+// user.
+func (f *surveyForm) questionField(q *survey.Question) (huh.Field, error) {
+	var (
+		title = fmt.Sprintf("[%s] %s", f.name, q.Message)
+	)
+
+	defaultValue, err := evaluateDefault(q.Default, f.options.previousAnswers, f.options)
+	if err != nil {
+		return nil, fmt.Errorf("question '%s' default: %w", q.Name, err)
+	}
+
+	previous, hasPrevious := f.options.Answers[q.Name]
+	if v, ok := previous.(string); ok {
+		defaultValue = v
+	}
+
+	switch q.Prompt {
+	case survey.PromptInput:
+		input := huh.NewInput().
+			Title(title).
+			Description(f.description(q)).
+			Placeholder(q.Placeholder)
+
+		if isTemplatedDefault(q.Default) {
+			// The default is displayed while the field is empty and is
+			// only used if the user does not provide a value.
+			f.templated[q.Name] = q.Default
+			input = input.PlaceholderFunc(f.templatedPlaceholder(q.Default), f.values)
+			if !hasPrevious {
+				defaultValue = ""
+			}
+		}
+
+		f.values[q.Name] = &defaultValue
+		input = input.Value(f.values[q.Name].(*string))
+		if q.Required && !isTemplatedDefault(q.Default) {
+			input = input.Validate(IsEmpty("cannot be empty"))
+		}
+
+		return input, nil
+
+	case survey.PromptSelect:
+		value := new(string)
+		f.values[q.Name] = value
+		prompt := huh.NewSelect[string]().
+			Title(title).
+			Description(f.description(q)).
+			Options(questionOptions(q, defaultValue)...).
+			Value(value)
+
+		if isTemplatedDefault(q.Default) && !hasPrevious {
+			return newLazyDefaultField(prompt, func() {
+				if v, ok := f.templatedDefault(q.Default); ok {
+					*value = v
+					prompt.Value(value)
+				}
+			}), nil
+		}
+
+		return prompt, nil
+
+	case survey.PromptMultiSelect:
+		f.values[q.Name] = new([]string)
+		selected, _ := previous.([]string)
+		prompt := huh.NewMultiSelect[string]().
+			Title(title).
+			Description(f.description(q)).
+			Options(questionOptions(q, selected...)...).
+			Value(f.values[q.Name].(*[]string))
+		if q.Required {
+			prompt = prompt.Validate(func(strings []string) error {
+				if len(strings) == 0 {
+					return errors.New("must choose at least one option")
+				}
+
+				return nil
+			})
+		}
+
+		return prompt, nil
+
+	case survey.PromptMultiline:
+		text := huh.NewText().
+			Title(title).
+			Description(f.description(q)).
+			Placeholder(q.Placeholder)
+
+		value, _ := previous.(string)
+		if isTemplatedDefault(q.Default) {
+			f.templated[q.Name] = q.Default
+			text = text.PlaceholderFunc(f.templatedPlaceholder(q.Default), f.values)
+		}
+
+		f.values[q.Name] = &value
+		text = text.Value(f.values[q.Name].(*string))
+		if q.Required && !isTemplatedDefault(q.Default) {
+			text = text.Validate(IsEmpty("cannot be empty"))
+		}
+
+		return text, nil
+
+	case survey.PromptSecret:
+		value, _ := previous.(string)
+		f.values[q.Name] = &value
+
+		input := huh.NewInput().
+			Title(title).
+			Description(f.description(q)).
+			Placeholder(q.Placeholder).
+			EchoMode(huh.EchoModePassword).
+			Value(f.values[q.Name].(*string))
+		if q.Required {
+			input = input.Validate(IsEmpty("cannot be empty"))
+		}
+
+		return input, nil
+
+	case survey.PromptFile, survey.PromptDirectory:
+		return f.pathField(q, title, previous), nil
+
+	case survey.PromptConfirm:
+		confirm := false
+		if defaultValue != "" {
+			if b, err := strconv.ParseBool(defaultValue); err == nil {
+				confirm = b
+			}
+		}
+		if b, ok := previous.(bool); ok {
+			confirm = b
+		}
+
+		f.values[q.Name] = &confirm
+		prompt := huh.NewConfirm().
+			Title(title).
+			Description(f.description(q)).
+			Value(&confirm)
+
+		if _, ok := previous.(bool); !ok && isTemplatedDefault(q.Default) {
+			return newLazyDefaultField(prompt, func() {
+				if v, ok := f.templatedDefault(q.Default); ok {
+					if b, err := strconv.ParseBool(v); err == nil {
+						confirm = b
+					}
+				}
+			}), nil
+		}
+
+		return prompt, nil
+	}
+
+	return nil, nil
+}
+
+// description returns the question description followed by the error of
+// its previous answer, if any.
+func (f *surveyForm) description(q *survey.Question) string {
+	message := f.options.Errors.Message(q.Name)
+	if message == "" {
+		return q.Description
+	}
+
+	message = "✗ " + message
+	if q.Description == "" {
+		return message
+	}
+
+	return q.Description + "\n" + message
+}
+
+// currentAnswers gives templated defaults access to the answers from a
+// parent survey and to the ones already typed in this form.
+func (f *surveyForm) currentAnswers() map[string]interface{} {
+	answers := make(map[string]interface{})
+	for k, v := range f.options.previousAnswers {
+		answers[k] = v
+	}
+	for k, v := range formAnswers(f.values) {
+		answers[k] = v
+	}
+
+	return answers
+}
+
+// templatedPlaceholder returns a function that renders a templated default
+// with the answers available at the moment the field is displayed.
+func (f *surveyForm) templatedPlaceholder(tpl string) func() string {
+	return func() string {
+		value, err := evaluateDefault(tpl, f.currentAnswers(), f.options)
+		if err != nil {
+			return ""
+		}
+
+		return value
+	}
+}
+
+// templatedDefault evaluates a templated default with the answers available
+// at the moment, telling if it could be evaluated.
+func (f *surveyForm) templatedDefault(tpl string) (string, bool) {
+	value, err := evaluateDefault(tpl, f.currentAnswers(), f.options)
+	if err != nil {
+		return "", false
+	}
+
+	return value, true
+}
+
+// answers returns the form answers after it is executed. Fields left empty
+// receive their templated default, evaluated with all answers from the
+// form.
+func (f *surveyForm) answers() (map[string]interface{}, error) {
+	for k, tpl := range f.templated {
+		if v := f.values[k].(*string); *v == "" {
+			value, err := evaluateDefault(tpl, f.currentAnswers(), f.options)
+			if err != nil {
+				return nil, fmt.Errorf("question '%s' default: %w", k, err)
+			}
+			*v = value
+		}
+	}
+
+	for k := range f.paths {
+		if v := f.values[k].(*string); *v != "" {
+			*v = f.toServicePath(*v)
+		}
+	}
+
+	return formAnswers(f.values), nil
+}
+
+// formAnswers converts the values bound to form fields into answers.
+func formAnswers(values map[string]interface{}) map[string]interface{} {
+	results := make(map[string]interface{})
 	for k, v := range values {
 		switch v := v.(type) {
 		case *string:
@@ -164,16 +537,23 @@ func runFormSurvey(name string, s *survey.Survey, options *FormOptions) (map[str
 		}
 	}
 
-	// Check if we have a follow-up survey to execute
-	if len(s.FollowUp) != 0 {
-		followUpResults, err := executeFollowUpSurvey(s.FollowUp, results, options)
-		if err != nil {
-			return nil, err
+	return results
+}
+
+// questionOptions builds the huh options of a question, displaying its
+// labels while keeping its values as answers.
+func questionOptions(q *survey.Question, selected ...string) []huh.Option[string] {
+	questionOptions := q.AllOptions()
+	options := make([]huh.Option[string], len(questionOptions))
+	for i, option := range questionOptions {
+		opt := huh.NewOption(option.DisplayLabel(), option.Value)
+		if slices.Contains(selected, option.Value) {
+			opt = opt.Selected(true)
 		}
-		results["follow-up"] = followUpResults
+		options[i] = opt
 	}
 
-	return results, nil
+	return options
 }
 
 func executeFollowUpSurvey(surveys []*survey.FollowUpSurvey, previousResults map[string]interface{}, options *FormOptions) (map[string]map[string]interface{}, error) {
@@ -186,7 +566,14 @@ func executeFollowUpSurvey(surveys []*survey.FollowUpSurvey, previousResults map
 			return nil, err
 		}
 		if ok {
-			r, err := RunFormFromSurvey(s.Name, s.Survey, options)
+			followUpOptions := *options
+			followUpOptions.previousAnswers = previousResults
+			followUpOptions.Answers = nil
+			if answers, ok := options.Answers[survey.FollowUpAnswersKey].(map[string]map[string]interface{}); ok {
+				followUpOptions.Answers = answers[s.Name]
+			}
+
+			r, err := RunFormFromSurvey(s.Name, s.Survey, &followUpOptions)
 			if err != nil {
 				return nil, err
 			}
@@ -200,40 +587,52 @@ func executeFollowUpSurvey(surveys []*survey.FollowUpSurvey, previousResults map
 }
 
 func checkFollowUpSurveyCondition(s *survey.FollowUpSurvey, previousResults map[string]interface{}) (bool, error) {
-	switch v := s.Condition.Value.(type) {
-	case string:
-		result, ok := previousResults[s.Condition.Name]
-		if !ok {
-			return false, nil
-		}
+	result, ok := previousResults[s.Condition.Name]
+	if !ok {
+		return false, nil
+	}
 
-		resultValue, ok := result.(string)
+	var values []string
+	switch v := s.Condition.Value.(type) {
+	case bool:
+		resultValue, ok := result.(bool)
 		if !ok {
 			return false, errors.New("invalid result value type found")
 		}
 
 		return resultValue == v, nil
 
-	case []interface{}:
-		result, ok := previousResults[s.Condition.Name]
-		if !ok {
-			return false, nil
-		}
+	case string:
+		values = []string{v}
 
-		resultValue, ok := result.(string)
-		if !ok {
-			return false, errors.New("invalid result value type found")
-		}
+	case []string:
+		values = v
 
-		values := make([]string, len(v))
-		for i, item := range v {
-			values[i] = item.(string)
+	case []interface{}:
+		for _, item := range v {
+			value, ok := item.(string)
+			if !ok {
+				return false, errors.New("invalid condition value type found")
+			}
+			values = append(values, value)
 		}
 
+	default:
+		return false, nil
+	}
+
+	switch resultValue := result.(type) {
+	case string:
 		return slices.Contains(values, resultValue), nil
+
+	case []string:
+		// Multi-select answers match when any chosen option does.
+		return slices.ContainsFunc(resultValue, func(r string) bool {
+			return slices.Contains(values, r)
+		}), nil
 	}
 
-	return false, nil
+	return false, errors.New("invalid result value type found")
 }
 
 func yesNo(message, defaultValue string) (bool, error) {
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+)
+
+// lazyDefaultField wraps a field whose templated default is only evaluated
+// when the field is reached, so it can reference the answers given to the
+// previous questions of the same form.
+type lazyDefaultField struct {
+	huh.Field
+	apply   func()
+	applied bool
+}
+
+func newLazyDefaultField(field huh.Field, apply func()) *lazyDefaultField {
+	return &lazyDefaultField{
+		Field: field,
+		apply: apply,
+	}
+}
+
+func (l *lazyDefaultField) Focus() tea.Cmd {
+	if !l.applied {
+		l.applied = true
+		l.apply()
+	}
+
+	return l.Field.Focus()
+}
+
+// Update keeps the wrapper in the form, since fields replace themselves
+// with the model they return.
+func (l *lazyDefaultField) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m, cmd := l.Field.Update(msg)
+	if field, ok := m.(huh.Field); ok {
+		l.Field = field
+	}
+
+	return l, cmd
+}
+
+func (l *lazyDefaultField) WithTheme(theme *huh.Theme) huh.Field {
+	l.Field = l.Field.WithTheme(theme)
+	return l
+}
+
+func (l *lazyDefaultField) WithAccessible(accessible bool) huh.Field {
+	l.Field = l.Field.WithAccessible(accessible)
+	return l
+}
+
+func (l *lazyDefaultField) WithKeyMap(k *huh.KeyMap) huh.Field {
+	l.Field = l.Field.WithKeyMap(k)
+	return l
+}
+
+func (l *lazyDefaultField) WithWidth(width int) huh.Field {
+	l.Field = l.Field.WithWidth(width)
+	return l
+}
+
+func (l *lazyDefaultField) WithHeight(height int) huh.Field {
+	l.Field = l.Field.WithHeight(height)
+	return l
+}
+
+func (l *lazyDefaultField) WithPosition(p huh.FieldPosition) huh.Field {
+	l.Field = l.Field.WithPosition(p)
+	return l
+}
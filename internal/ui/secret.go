@@ -0,0 +1,209 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/iancoleman/strcase"
+
+	"github.com/mikros-dev/mikros-cli/pkg/survey"
+)
+
+// RemoveSecrets returns a copy of the survey answers without the values
+// of secret questions, so they can be used outside the plugin without
+// leaking them.
+func RemoveSecrets(s *survey.Survey, answers map[string]interface{}) map[string]interface{} {
+	return transformSecrets(s, answers, func(_ string, _ interface{}) (interface{}, bool) {
+		return nil, false
+	})
+}
+
+// ReferenceSecrets returns a copy of the survey answers where the values of
+// secret questions are replaced by references to environment variables
+// named after the questions, like ${DATABASE_PASSWORD}.
+func ReferenceSecrets(s *survey.Survey, answers map[string]interface{}) map[string]interface{} {
+	return transformSecrets(s, answers, func(name string, _ interface{}) (interface{}, bool) {
+		return survey.EnvReference(strcase.ToScreamingSnake(name)), true
+	})
+}
+
+// ExpandSecrets returns a copy of the survey answers where the values of
+// secret questions, previously replaced by ReferenceSecrets, are loaded from
+// the environment. Referenced variables that are not set are an error,
+// so secrets are never silently left empty.
+func ExpandSecrets(s *survey.Survey, answers map[string]interface{}) (map[string]interface{}, error) {
+	var expandErr error
+	expanded := transformSecrets(s, answers, func(name string, value interface{}) (interface{}, bool) {
+		v, ok := value.(string)
+		if !ok {
+			return value, true
+		}
+
+		return os.Expand(v, func(key string) string {
+			env, ok := os.LookupEnv(key)
+			if !ok && expandErr == nil {
+				expandErr = fmt.Errorf("secret '%s' references the environment variable %s, which is not set", name, key)
+			}
+
+			return env
+		}), true
+	})
+	if expandErr != nil {
+		return nil, expandErr
+	}
+
+	return expanded, nil
+}
+
+// transformSecrets returns a copy of the answers where fn is applied to the
+// value of every secret question. Values are removed if fn returns false.
+func transformSecrets(
+	s *survey.Survey,
+	answers map[string]interface{},
+	fn func(name string, value interface{}) (interface{}, bool),
+) map[string]interface{} {
+	names := secretNames(s)
+	if len(names) == 0 {
+		return answers
+	}
+
+	return transformValue(names, answers, fn).(map[string]interface{})
+}
+
+func transformValue(
+	names map[string]bool,
+	value interface{},
+	fn func(name string, value interface{}) (interface{}, bool),
+) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			if names[k] {
+				if newValue, ok := fn(k, item); ok {
+					out[k] = newValue
+				}
+				continue
+			}
+			out[k] = transformValue(names, item, fn)
+		}
+
+		return out
+
+	case map[string]map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			out[k] = transformValue(names, item, fn)
+		}
+
+		return out
+
+	case []map[string]interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = transformValue(names, item, fn)
+		}
+
+		return out
+
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = transformValue(names, item, fn)
+		}
+
+		return out
+	}
+
+	return value
+}
+
+// CheckSecrets makes sure that definitions returned by a plugin do not
+// hold any secret answered by the user as plaintext.
+func CheckSecrets(s *survey.Survey, answers map[string]interface{}, definitions interface{}) error {
+	names := secretNames(s)
+	if len(names) == 0 {
+		return nil
+	}
+
+	secrets := make(map[string]string)
+	collectSecrets(names, answers, secrets)
+
+	return checkDefinitions(secrets, definitions)
+}
+
+func collectSecrets(names map[string]bool, value interface{}, secrets map[string]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, item := range v {
+			if s, ok := item.(string); ok && names[k] && s != "" {
+				secrets[s] = k
+				continue
+			}
+			collectSecrets(names, item, secrets)
+		}
+
+	case map[string]map[string]interface{}:
+		for _, item := range v {
+			collectSecrets(names, item, secrets)
+		}
+
+	case []map[string]interface{}:
+		for _, item := range v {
+			collectSecrets(names, item, secrets)
+		}
+
+	case []interface{}:
+		for _, item := range v {
+			collectSecrets(names, item, secrets)
+		}
+	}
+}
+
+func checkDefinitions(secrets map[string]string, value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		if name, ok := secrets[v]; ok {
+			return fmt.Errorf("definitions contain the plaintext value of secret '%s', use a reference like %s instead", name, survey.EnvReference("NAME"))
+		}
+
+	case map[string]interface{}:
+		for _, item := range v {
+			if err := checkDefinitions(secrets, item); err != nil {
+				return err
+			}
+		}
+
+	case []interface{}:
+		for _, item := range v {
+			if err := checkDefinitions(secrets, item); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// secretNames returns the names of all secret questions of a survey,
+// including the ones from its follow-up surveys.
+func secretNames(s *survey.Survey) map[string]bool {
+	names := make(map[string]bool)
+	if s == nil {
+		return names
+	}
+
+	for _, q := range s.AllQuestions() {
+		if q.Prompt == survey.PromptSecret {
+			names[q.Name] = true
+		}
+	}
+
+	for _, f := range s.FollowUp {
+		for name := range secretNames(f.Survey) {
+			names[name] = true
+		}
+	}
+
+	return names
+}
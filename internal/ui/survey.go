@@ -1,6 +1,8 @@
 package ui
 
 import (
+	"fmt"
+
 	"github.com/mikros-dev/mikros-cli/pkg/survey"
 )
 
@@ -15,3 +17,56 @@ func SurveyConfirmAfter(s *survey.Survey) bool {
 func SurveyNeedsConfirmation(s *survey.Survey) bool {
 	return s.ConfirmQuestion != nil
 }
+
+// SurveyPages returns the pages of a survey. Questions declared outside
+// pages are gathered into an initial page.
+func SurveyPages(s *survey.Survey) []*survey.Page {
+	var pages []*survey.Page
+	if len(s.Questions) > 0 {
+		pages = append(pages, &survey.Page{
+			Questions: s.Questions,
+		})
+	}
+
+	return append(pages, s.Pages...)
+}
+
+// SurveyHasQuestion checks if a survey, or any of its follow-up surveys,
+// has a question named name.
+func SurveyHasQuestion(s *survey.Survey, name string) bool {
+	if s == nil {
+		return false
+	}
+
+	for _, q := range s.AllQuestions() {
+		if q.Name == name {
+			return true
+		}
+	}
+
+	for _, f := range s.FollowUp {
+		if SurveyHasQuestion(f.Survey, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LoopEntryLabel returns the label of an entry of a survey executed inside
+// a loop, like "Event 2: user-created".
+func LoopEntryLabel(name string, s *survey.Survey, index int, answers map[string]interface{}) string {
+	entryName := s.EntryName
+	if entryName == "" {
+		entryName = name
+	}
+
+	label := fmt.Sprintf("%s %d", entryName, index+1)
+	if s.KeyQuestion != "" {
+		if value := fmt.Sprint(answers[s.KeyQuestion]); answers[s.KeyQuestion] != nil && value != "" {
+			label += ": " + value
+		}
+	}
+
+	return label
+}
@@ -30,6 +30,17 @@ type ServiceApi interface {
 	Template(in map[string]interface{}) *template.Template
 }
 
+// ManifestsApi is an optional API that a service plugin can implement to add
+// Kubernetes resources of its own to the manifests of its services, created
+// by 'mikros deploy manifests'.
+type ManifestsApi interface {
+	// Manifests receives the settings of the service kind, from the
+	// 'service.toml' file, and returns templates of the additional
+	// resources. They are executed with the same context of the built-in
+	// manifests, with their custom Context available as {{.PluginData}}.
+	Manifests(in map[string]interface{}) []*template.File
+}
+
 // Service is the service plugin object that provides the channel that mikros
 // CLI recognizes as a plugin.
 type Service struct {
@@ -55,6 +66,7 @@ func (s *Service) Run() error {
 	vFlag := flag.Bool("v", false, "Validate answers")
 	tFlag := flag.Bool("t", false, "Retrieve plugin custom templates")
 	kFlag := flag.Bool("k", false, "Get service kind")
+	mFlag := flag.Bool("m", false, "Retrieve plugin custom manifests")
 	input := flag.String("i", "", "Input values for plugin arguments")
 	flag.Parse()
 
@@ -90,6 +102,18 @@ func (s *Service) Run() error {
 		encoder.SetTemplate(s.api.Template(in))
 	case *kFlag:
 		encoder.SetKind(s.api.Kind())
+	case *mFlag:
+		if *input == "" {
+			return errors.New("invalid input")
+		}
+		in, err := inputToMap(*input)
+		if err != nil {
+			return err
+		}
+
+		if api, ok := s.api.(ManifestsApi); ok {
+			encoder.SetManifests(api.Manifests(in))
+		}
 	default:
 		return errors.New("no valid command specified")
 	}
@@ -0,0 +1,66 @@
+package survey
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidationError is an error that a plugin can return from its
+// ValidateAnswers method to point which answers are invalid. Instead of
+// aborting, mikros CLI presents the survey again, with the previous answers
+// and the error messages next to their questions.
+type ValidationError struct {
+	Fields []*FieldError `json:"fields,omitempty"`
+}
+
+// FieldError is the validation error of a single question.
+type FieldError struct {
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
+
+// NewValidationError creates an empty ValidationError.
+func NewValidationError() *ValidationError {
+	return &ValidationError{}
+}
+
+// Add adds an error message for the question named name.
+func (v *ValidationError) Add(name, message string) *ValidationError {
+	v.Fields = append(v.Fields, &FieldError{
+		Name:    name,
+		Message: message,
+	})
+
+	return v
+}
+
+// HasErrors returns if any question error was added.
+func (v *ValidationError) HasErrors() bool {
+	return v != nil && len(v.Fields) > 0
+}
+
+// Message returns the error message of a question or an empty string if it
+// has no errors.
+func (v *ValidationError) Message(name string) string {
+	if v == nil {
+		return ""
+	}
+
+	var messages []string
+	for _, f := range v.Fields {
+		if f.Name == name {
+			messages = append(messages, f.Message)
+		}
+	}
+
+	return strings.Join(messages, ", ")
+}
+
+func (v *ValidationError) Error() string {
+	errs := make([]string, len(v.Fields))
+	for i, f := range v.Fields {
+		errs[i] = fmt.Sprintf("%s: %s", f.Name, f.Message)
+	}
+
+	return "invalid answers: " + strings.Join(errs, "; ")
+}
@@ -0,0 +1,292 @@
+package survey
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	schemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+	// FollowUpAnswersKey is the key where answers of follow-up surveys are
+	// stored.
+	FollowUpAnswersKey = "follow-up"
+)
+
+// Schema is a JSON Schema describing the answers of a survey.
+type Schema struct {
+	Dialect              string             `json:"$schema,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	WriteOnly            bool               `json:"writeOnly,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+}
+
+// JSONSchema returns a JSON Schema of the answers produced by the survey
+// when it is executed with the given name.
+func (s *Survey) JSONSchema(name string) *Schema {
+	schema := s.answersSchema(name)
+	schema.Dialect = schemaDialect
+	schema.Title = name
+
+	return schema
+}
+
+func (s *Survey) answersSchema(name string) *Schema {
+	answers := s.iterationSchema()
+	if s.ConfirmQuestion == nil {
+		return answers
+	}
+
+	// Surveys inside a loop have their answers as a list of iterations.
+	entries := &Schema{
+		Type:  "array",
+		Items: answers,
+	}
+	if s.MinIterations > 0 {
+		entries.MinItems = intPtr(s.MinIterations)
+	}
+	if s.MaxIterations > 0 {
+		entries.MaxItems = intPtr(s.MaxIterations)
+	}
+
+	return &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			name: entries,
+		},
+		Required:             []string{name},
+		AdditionalProperties: new(bool),
+	}
+}
+
+func (s *Survey) iterationSchema() *Schema {
+	schema := &Schema{
+		Type:       "object",
+		Properties: make(map[string]*Schema),
+	}
+
+	for _, q := range s.AllQuestions() {
+		schema.Properties[q.Name] = q.schema()
+		if q.Required {
+			schema.Required = append(schema.Required, q.Name)
+		}
+	}
+
+	if len(s.FollowUp) > 0 {
+		followUp := &Schema{
+			Type:                 "object",
+			Properties:           make(map[string]*Schema),
+			AdditionalProperties: new(bool),
+		}
+
+		// Follow-up answers are only present when their condition is met.
+		for _, f := range s.FollowUp {
+			if f.Survey == nil {
+				continue
+			}
+
+			followUp.Properties[f.Name] = f.Survey.answersSchema(f.Name)
+		}
+
+		schema.Properties[FollowUpAnswersKey] = followUp
+	}
+
+	return schema
+}
+
+func (q *Question) schema() *Schema {
+	schema := &Schema{
+		Title:       q.Message,
+		Description: q.Description,
+		Type:        "string",
+	}
+
+	var values []string
+	for _, o := range q.AllOptions() {
+		if o != nil {
+			values = append(values, o.Value)
+		}
+	}
+
+	templated := strings.Contains(q.Default, "{{")
+
+	switch q.Prompt {
+	case PromptSelect:
+		schema.Enum = values
+	case PromptMultiSelect:
+		schema.Type = "array"
+		schema.Items = &Schema{
+			Type: "string",
+			Enum: values,
+		}
+		if q.Required {
+			schema.MinItems = intPtr(1)
+		}
+	case PromptConfirm:
+		schema.Type = "boolean"
+		if b, err := strconv.ParseBool(q.Default); err == nil {
+			schema.Default = b
+		}
+		return schema
+	case PromptSecret:
+		schema.Format = "password"
+		schema.WriteOnly = true
+	}
+
+	if q.Required && schema.Type == "string" {
+		schema.MinLength = intPtr(1)
+	}
+	if q.Prompt == PromptSecret {
+		return schema
+	}
+
+	// Paths are answered relative to the service directory, while the
+	// default is only where the user starts browsing.
+	if q.Prompt == PromptFile || q.Prompt == PromptDirectory {
+		return schema
+	}
+
+	if q.Default != "" && !templated && q.Prompt != PromptMultiSelect {
+		schema.Default = q.Default
+	}
+
+	return schema
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+// Validate checks if value, usually decoded from JSON, follows the schema.
+// It supports the subset of JSON Schema used to describe survey answers.
+func (s *Schema) Validate(value interface{}) error {
+	var problems []error
+	s.validate("answers", value, &problems)
+
+	return errors.Join(problems...)
+}
+
+func (s *Schema) validate(path string, value interface{}, problems *[]error) {
+	report := func(format string, args ...interface{}) {
+		*problems = append(*problems, fmt.Errorf("%s: %s", path, fmt.Sprintf(format, args...)))
+	}
+
+	switch s.Type {
+	case "string":
+		v, ok := value.(string)
+		if !ok {
+			report("must be a string")
+			return
+		}
+		if s.MinLength != nil && len(v) < *s.MinLength {
+			report("cannot be empty")
+		}
+		if len(s.Enum) > 0 && !slices.Contains(s.Enum, v) {
+			report("must be one of %s", strings.Join(s.Enum, ", "))
+		}
+
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			report("must be a boolean")
+		}
+
+	case "array":
+		items, ok := toList(value)
+		if !ok {
+			report("must be a list")
+			return
+		}
+		if s.MinItems != nil && len(items) < *s.MinItems {
+			report("must have at least %d items", *s.MinItems)
+		}
+		if s.MaxItems != nil && len(items) > *s.MaxItems {
+			report("must have at most %d items", *s.MaxItems)
+		}
+		if s.Items != nil {
+			for i, item := range items {
+				s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, problems)
+			}
+		}
+
+	case "object":
+		object, ok := toObject(value)
+		if !ok {
+			report("must be an object")
+			return
+		}
+
+		for _, name := range s.Required {
+			if _, ok := object[name]; !ok {
+				report("missing required answer '%s'", name)
+			}
+		}
+
+		keys := make([]string, 0, len(object))
+		for k := range object {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			property, ok := s.Properties[k]
+			if !ok {
+				if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+					report("unknown answer '%s'", k)
+				}
+				continue
+			}
+			property.validate(path+"."+k, object[k], problems)
+		}
+	}
+}
+
+func toList(value interface{}) ([]interface{}, bool) {
+	switch v := value.(type) {
+	case []interface{}:
+		return v, true
+	case []string:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = item
+		}
+		return items, true
+	case []map[string]interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = item
+		}
+		return items, true
+	}
+
+	return nil, false
+}
+
+func toObject(value interface{}) (map[string]interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, true
+	case map[string]map[string]interface{}:
+		object := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			object[k] = item
+		}
+		return object, true
+	}
+
+	return nil, false
+}
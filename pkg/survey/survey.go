@@ -8,6 +8,23 @@ type Survey struct {
 	// decides to stop.
 	ConfirmQuestion *Question `json:"confirm_question,omitempty"`
 
+	// MinIterations is the minimum number of entries that a survey inside
+	// a loop must have. The confirm question is not asked until it is
+	// reached.
+	MinIterations int `json:"min_iterations,omitempty"`
+
+	// MaxIterations is the maximum number of entries that a survey inside
+	// a loop can have. Zero means no limit.
+	MaxIterations int `json:"max_iterations,omitempty"`
+
+	// EntryName is the name used to label each entry of a survey inside a
+	// loop, like "Event". If empty, the survey name is used.
+	EntryName string `json:"entry_name,omitempty"`
+
+	// KeyQuestion is an optional name of a question whose answer also
+	// labels each entry of a survey inside a loop, like "Event 2: created".
+	KeyQuestion string `json:"key_question,omitempty"`
+
 	// Questions gathers a list of questions that will be presented to the
 	// user.
 	Questions []*Question `json:"questions,omitempty"`
@@ -16,6 +33,19 @@ type Survey struct {
 	// internal condition (these must have Condition adjusted or won't
 	// be validated and executed).
 	FollowUp []*FollowUpSurvey `json:"sub_survey,omitempty"`
+
+	// Pages splits the survey questions into pages, where the user can go
+	// back and edit answers from previous pages before submitting. When
+	// Questions is also set, its questions are presented in a first page.
+	Pages []*Page `json:"pages,omitempty"`
+}
+
+// Page is a named set of questions displayed together.
+type Page struct {
+	Name        string      `json:"name,omitempty" validate:"required"`
+	Title       string      `json:"title,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Questions   []*Question `json:"questions,omitempty"`
 }
 
 type Question struct {
@@ -24,8 +54,78 @@ type Question struct {
 	Prompt       PromptKind `json:"prompt" validate:"required"`
 	Message      string     `json:"message,omitempty"`
 	Name         string     `json:"name" validate:"required"`
-	Default      string     `json:"default,omitempty"`
 	Options      []string   `json:"options,omitempty"`
+
+	// LabeledOptions are choices of select and multi-select questions that
+	// display a label, and optionally a hint, instead of their values. They
+	// are presented after Options.
+	LabeledOptions []*Option `json:"labeled_options,omitempty"`
+
+	// Default is the question default value. It can be a template using
+	// the same functions available for custom templates (toSnake, toKebab,
+	// etc.) and receiving the following data:
+	//
+	// {{.ServiceName}}: the name of the service being created.
+	// {{.ServiceType}}: the type of the service being created.
+	// {{.Product}}: the product that the service belongs to.
+	// {{.Language}}: the service programming language.
+	// {{.Answers}}: a map with answers already given, like {{.Answers.name}}.
+	Default string `json:"default,omitempty"`
+
+	// Description is an optional help text displayed below the question
+	// message.
+	Description string `json:"description,omitempty"`
+
+	// Placeholder is an optional text displayed inside empty input and
+	// multiline prompts.
+	Placeholder string `json:"placeholder,omitempty"`
+
+	// Extensions restricts which files can be chosen by a PromptFile
+	// question, like ".proto" or ".json".
+	Extensions []string `json:"extensions,omitempty"`
+
+	// MustExist makes PromptFile and PromptDirectory questions only accept
+	// paths that already exist.
+	MustExist bool `json:"must_exist,omitempty"`
+}
+
+// Option is a choice of a select or multi-select question. Label is what
+// the user sees, while Value is what is stored as the answer.
+type Option struct {
+	// Label is the text displayed for the option. If empty, Value is used.
+	Label string `json:"label,omitempty"`
+
+	// Value is the value returned as the answer when the option is chosen.
+	Value string `json:"value"`
+
+	// Hint is an optional short text displayed next to the option label.
+	Hint string `json:"hint,omitempty"`
+}
+
+// AllOptions returns the choices of a select or multi-select question,
+// from both Options and LabeledOptions.
+func (q *Question) AllOptions() []*Option {
+	options := make([]*Option, 0, len(q.Options)+len(q.LabeledOptions))
+	for _, v := range q.Options {
+		options = append(options, &Option{
+			Value: v,
+		})
+	}
+
+	return append(options, q.LabeledOptions...)
+}
+
+// DisplayLabel returns the text that should be displayed for the option.
+func (o *Option) DisplayLabel() string {
+	label := o.Label
+	if label == "" {
+		label = o.Value
+	}
+	if o.Hint != "" {
+		label += " - " + o.Hint
+	}
+
+	return label
 }
 
 type FollowUpSurvey struct {
@@ -35,7 +135,11 @@ type FollowUpSurvey struct {
 }
 
 type QuestionCondition struct {
-	Name  string      `json:"name,omitempty"`
+	Name string `json:"name,omitempty"`
+
+	// Value is the answer that triggers the survey: a string, or a list of
+	// strings where any of them matches, or a bool for confirm questions.
+	// Multi-select questions match when any chosen option does.
 	Value interface{} `json:"value,omitempty"`
 }
 
@@ -47,4 +151,21 @@ const (
 	PromptMultiSelect
 	PromptMultiline
 	PromptConfirm
+
+	// PromptSecret is an input prompt with masked characters. Its answer
+	// is only handed to the plugin, which must not return it inside the
+	// definitions written into 'service.toml', but a reference to it,
+	// like the one built by EnvReference.
+	PromptSecret
+
+	// PromptFile and PromptDirectory let the user pick a path, which is
+	// answered relative to the service directory.
+	PromptFile
+	PromptDirectory
 )
+
+// EnvReference returns a reference to an environment variable that can be
+// written into 'service.toml' in place of a secret value.
+func EnvReference(name string) string {
+	return "${" + name + "}"
+}
@@ -0,0 +1,210 @@
+package survey
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// AllQuestions returns all questions of the survey, the ones declared
+// directly in it followed by the ones declared inside its pages.
+func (s *Survey) AllQuestions() []*Question {
+	questions := slices.Clone(s.Questions)
+	for _, page := range s.Pages {
+		questions = append(questions, page.Questions...)
+	}
+
+	return questions
+}
+
+// Validate checks the survey structure, including its follow-up surveys,
+// returning all problems found.
+func (s *Survey) Validate() error {
+	var problems []error
+	s.validate("survey", &problems)
+
+	return errors.Join(problems...)
+}
+
+func (s *Survey) validate(scope string, problems *[]error) {
+	report := func(format string, args ...interface{}) {
+		*problems = append(*problems, fmt.Errorf("%s: %s", scope, fmt.Sprintf(format, args...)))
+	}
+
+	if s.ConfirmQuestion != nil && s.ConfirmQuestion.Message == "" {
+		report("confirm question has no message")
+	}
+
+	if s.ConfirmQuestion == nil && (s.MinIterations != 0 || s.MaxIterations != 0 || s.KeyQuestion != "") {
+		report("loop settings require a confirm question")
+	}
+	if s.MinIterations < 0 || s.MaxIterations < 0 {
+		report("loop iterations cannot be negative")
+	}
+	if s.MaxIterations > 0 && s.MinIterations > s.MaxIterations {
+		report("loop minimum iterations is greater than its maximum")
+	}
+
+	pages := make(map[string]bool)
+	for i, page := range s.Pages {
+		if page.Name == "" {
+			report("page %d has no name", i)
+			continue
+		}
+		if pages[page.Name] {
+			report("page '%s' is declared more than once", page.Name)
+		}
+		pages[page.Name] = true
+
+		if len(page.Questions) == 0 {
+			report("page '%s' has no questions", page.Name)
+		}
+	}
+
+	questions := s.AllQuestions()
+	if len(questions) == 0 {
+		report("survey has no questions")
+	}
+
+	names := make(map[string]*Question)
+	for i, q := range questions {
+		if q.Name == "" {
+			report("question %d has no name", i)
+			continue
+		}
+		if _, ok := names[q.Name]; ok {
+			report("question '%s' is declared more than once", q.Name)
+		} else {
+			names[q.Name] = q
+		}
+
+		for _, problem := range q.validate() {
+			report("question '%s' %s", q.Name, problem)
+		}
+	}
+
+	if s.KeyQuestion != "" {
+		if _, ok := names[s.KeyQuestion]; !ok {
+			report("loop key question '%s' is not a survey question", s.KeyQuestion)
+		}
+	}
+
+	followUps := make(map[string]bool)
+	for i, f := range s.FollowUp {
+		if f.Name == "" {
+			report("follow-up survey %d has no name", i)
+			continue
+		}
+		if followUps[f.Name] {
+			report("follow-up survey '%s' is declared more than once", f.Name)
+		}
+		followUps[f.Name] = true
+
+		for _, problem := range f.validate(names) {
+			report("follow-up survey '%s' %s", f.Name, problem)
+		}
+
+		if f.Survey != nil {
+			f.Survey.validate(fmt.Sprintf("%s > %s", scope, f.Name), problems)
+		}
+	}
+}
+
+func (q *Question) validate() []string {
+	var problems []string
+
+	if q.Prompt < PromptInput || q.Prompt > PromptDirectory {
+		problems = append(problems, fmt.Sprintf("has an unsupported prompt kind %d", q.Prompt))
+	}
+
+	if len(q.Extensions) > 0 && q.Prompt != PromptFile {
+		problems = append(problems, "has extensions but is not a file prompt")
+	}
+	if q.MustExist && q.Prompt != PromptFile && q.Prompt != PromptDirectory {
+		problems = append(problems, "must exist but is not a file or directory prompt")
+	}
+
+	if q.Prompt != PromptSelect && q.Prompt != PromptMultiSelect {
+		return problems
+	}
+
+	options := q.AllOptions()
+	if len(options) == 0 {
+		return append(problems, "has no options")
+	}
+
+	values := make(map[string]bool)
+	for i, option := range options {
+		if option == nil || option.Value == "" {
+			problems = append(problems, fmt.Sprintf("option %d has no value", i))
+			continue
+		}
+		if values[option.Value] {
+			problems = append(problems, fmt.Sprintf("option '%s' is declared more than once", option.Value))
+		}
+		values[option.Value] = true
+	}
+
+	// Templated defaults are only known when the survey executes.
+	if q.Prompt == PromptSelect && q.Default != "" && !strings.Contains(q.Default, "{{") && !values[q.Default] {
+		problems = append(problems, fmt.Sprintf("default '%s' is not one of its options", q.Default))
+	}
+
+	return problems
+}
+
+func (f *FollowUpSurvey) validate(questions map[string]*Question) []string {
+	var problems []string
+
+	if f.Survey == nil {
+		problems = append(problems, "has no survey")
+	}
+
+	if f.Condition == nil {
+		return append(problems, "has no condition")
+	}
+
+	q, ok := questions[f.Condition.Name]
+	if !ok {
+		return append(problems, fmt.Sprintf("condition references unknown question '%s'", f.Condition.Name))
+	}
+
+	if q.Prompt == PromptConfirm {
+		if _, ok := f.Condition.Value.(bool); !ok {
+			problems = append(problems, fmt.Sprintf("condition value must be a bool for confirm question '%s'", q.Name))
+		}
+
+		return problems
+	}
+
+	var values []string
+	switch v := f.Condition.Value.(type) {
+	case string:
+		values = []string{v}
+	case []string:
+		values = v
+	case []interface{}:
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return append(problems, "condition values must be strings")
+			}
+			values = append(values, s)
+		}
+	default:
+		return append(problems, "condition value must be a string or a list of strings")
+	}
+
+	if q.Prompt != PromptSelect && q.Prompt != PromptMultiSelect {
+		return problems
+	}
+
+	for _, value := range values {
+		if !slices.ContainsFunc(q.AllOptions(), func(o *Option) bool { return o != nil && o.Value == value }) {
+			problems = append(problems, fmt.Sprintf("condition value '%s' is not an option of question '%s'", value, q.Name))
+		}
+	}
+
+	return problems
+}
@@ -1,5 +1,11 @@
 package template
 
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+)
+
 type Template struct {
 	// NewServiceArgs Allows adding custom content for external service kind
 	// when creating the main file of a new template service. It will be available
@@ -26,15 +32,79 @@ type Template struct {
 	// Templates contains a list of custom template files that will be generated
 	// when the service is selected for a service.
 	Templates []*File `json:"templates,omitempty"`
+
+	// Tests contains test files, like "worker_test.go", generated along with
+	// the other templates only when unit tests are asked for the service.
+	// The service unit tests provide a TestMain initializing the service
+	// into the 'svc' variable.
+	Tests []*File `json:"tests,omitempty"`
+
+	// Partials are templates, by their names, that can be called from any
+	// plugin template with {{template "name" .}}. The built-in partials, like
+	// "imports" and "header", are also available.
+	Partials map[string]string `json:"partials,omitempty"`
 }
 
 type File struct {
-	Content   string `json:"content,omitempty"`
-	Name      string `json:"name,omitempty"`
+	// Content is the template content or, when Encoding is set, the
+	// encoded file content.
+	Content string `json:"content,omitempty"`
+	Name    string `json:"name,omitempty"`
+
+	// Output is the path of the generated file, without its extension,
+	// relative to the service directory. It can have subdirectories, like
+	// "internal/handlers/event", which are created when needed. Absolute
+	// paths or paths leaving the service directory are refused.
 	Output    string `json:"output,omitempty"`
 	Extension string `json:"extension,omitempty"`
 
+	// Mode is an optional permission of the generated file, like 0755 for
+	// executable scripts.
+	Mode os.FileMode `json:"mode,omitempty"`
+
+	// Raw makes the content to be written as is, without being executed as
+	// a template. Useful for static assets that have their own template
+	// syntax.
+	Raw bool `json:"raw,omitempty"`
+
+	// Encoding sets how Content is encoded. It allows shipping binary files,
+	// like images or fixtures, which are never executed as templates.
+	Encoding Encoding `json:"encoding,omitempty"`
+
+	// When is an optional template expression that decides if the file is
+	// generated, like "{{.HasOnStart}}" or "{{eq .PluginData.Kind \"sql\"}}".
+	// It is evaluated against the same context of the template and the file
+	// is skipped when it results in an empty value, "false" or "0".
+	When string `json:"when,omitempty"`
+
 	// Context is a custom context to be used inside custom templates exported
 	// by the plugin.
 	Context interface{} `json:"context,omitempty"`
 }
+
+// Encoding is the encoding of a template file content.
+type Encoding string
+
+const (
+	// EncodingBase64 is used for binary files, with their content encoded
+	// with the standard base64 encoding.
+	EncodingBase64 Encoding = "base64"
+)
+
+// Bytes returns the file content, decoded if needed.
+func (f *File) Bytes() ([]byte, error) {
+	switch f.Encoding {
+	case "":
+		return []byte(f.Content), nil
+	case EncodingBase64:
+		return base64.StdEncoding.DecodeString(f.Content)
+	}
+
+	return nil, fmt.Errorf("unsupported file encoding '%s'", f.Encoding)
+}
+
+// IsRaw checks if the file content must be written without being executed
+// as a template.
+func (f *File) IsRaw() bool {
+	return f.Raw || f.Encoding != ""
+}